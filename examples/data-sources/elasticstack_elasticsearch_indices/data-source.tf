@@ -0,0 +1,20 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+data "elasticstack_elasticsearch_indices" "logs" {
+  search           = "logs-*"
+  expand_wildcards = "open,closed"
+}
+
+output "index_names" {
+  value = data.elasticstack_elasticsearch_indices.logs.indices[*].name
+}
+
+output "unhealthy_indices" {
+  value = [for index in data.elasticstack_elasticsearch_indices.logs.indices : index.name if index.health != "green"]
+}
+
+output "replicas" {
+  value = { for index in data.elasticstack_elasticsearch_indices.logs.indices : index.name => index.number_of_replicas }
+}
@@ -0,0 +1,6 @@
+provider "elasticstack" {
+  kibana {
+    api_key   = "base64encodedapikeyhere=="
+    endpoints = ["http://localhost:5601"]
+  }
+}
@@ -0,0 +1,6 @@
+provider "elasticstack" {
+  elasticsearch {
+    cloud_id   = "my-deployment:dXMtZWFzdC0xLmF3cy5mb3VuZC5pbyRlcy1pZCRrYi1pZA=="
+    cloud_auth = "elastic:changeme"
+  }
+}
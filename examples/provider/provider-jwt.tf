@@ -0,0 +1,7 @@
+provider "elasticstack" {
+  elasticsearch {
+    bearer_token             = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.payload.signature"
+    es_client_authentication = "jwt-realm-shared-secret"
+    endpoints                = ["https://localhost:9200"]
+  }
+}
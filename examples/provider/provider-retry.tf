@@ -0,0 +1,14 @@
+provider "elasticstack" {
+  elasticsearch {
+    username  = "elastic"
+    password  = "changeme"
+    endpoints = ["http://localhost:9200"]
+  }
+
+  retry {
+    max_retries            = 5
+    min_backoff            = "2s"
+    max_backoff            = "1m"
+    retryable_status_codes = [429, 502, 503]
+  }
+}
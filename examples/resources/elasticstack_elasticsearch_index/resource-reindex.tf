@@ -0,0 +1,10 @@
+resource "elasticstack_elasticsearch_index" "my_reindexed_index" {
+  name                    = "my-reindexed-index"
+  mapping_change_strategy = "reindex"
+
+  mappings = jsonencode({
+    properties = {
+      field1 = { type = "keyword" }
+    }
+  })
+}
@@ -33,4 +33,8 @@ resource "elasticstack_elasticsearch_index" "my_index" {
   number_of_replicas    = 2
   search_idle_after     = "20s"
   total_shards_per_node = 200
+
+  settings_raw = jsonencode({
+    "index.mapping.total_fields.limit" = 2000
+  })
 }
@@ -0,0 +1,23 @@
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_alias" "logs" {
+  name = "logs"
+
+  index {
+    name           = "logs-2024.02"
+    is_write_index = true
+  }
+
+  index {
+    name = "logs-2024.01"
+  }
+
+  index {
+    name = "audit-logs"
+    filter = jsonencode({
+      term = { "event.category" = "authentication" }
+    })
+  }
+}
@@ -0,0 +1 @@
+terraform import elasticstack_elasticsearch_security_api_key.api_key <cluster_uuid>/<api_key_id>
@@ -0,0 +1,27 @@
+resource "elasticstack_elasticsearch_security_api_key" "rotated" {
+  name = "My rotated API key"
+
+  role_descriptors = jsonencode({
+    role-a = {
+      cluster = ["monitor"]
+    }
+  })
+
+  # Rotate the API key every 30 days, keeping the previous key valid for 7 more days
+  rotation_period  = "30d"
+  rotation_overlap = "7d"
+
+  # Changing any of the keepers rotates the API key immediately
+  keepers = {
+    owner = "team-a"
+  }
+}
+
+# Both credentials are valid during the overlap
+output "api_keys" {
+  value = compact([
+    elasticstack_elasticsearch_security_api_key.rotated.encoded,
+    elasticstack_elasticsearch_security_api_key.rotated.previous_encoded,
+  ])
+  sensitive = true
+}
@@ -0,0 +1,35 @@
+resource "elasticstack_elasticsearch_security_cross_cluster_api_key" "api_key" {
+  name = "My cross-cluster API key"
+
+  access {
+    # Indices the remote cluster can search
+    search {
+      names = ["logs-*"]
+      field_security {
+        grant = ["@timestamp", "message"]
+      }
+      query = jsonencode({
+        term = { "service.name" = "frontend" }
+      })
+    }
+
+    # Indices the remote cluster can replicate
+    replication {
+      names = ["archive-*"]
+    }
+  }
+
+  # Set the expiration for the API key
+  expiration = "30d"
+
+  # Set the custom metadata for this API key
+  metadata = jsonencode({
+    "remote_cluster" = "cluster-b"
+  })
+}
+
+# Configure the `encoded` credentials as the `cluster.remote.<alias>.credentials` secure setting of the remote cluster.
+output "encoded" {
+  value     = elasticstack_elasticsearch_security_cross_cluster_api_key.api_key.encoded
+  sensitive = true
+}
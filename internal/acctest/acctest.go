@@ -4,8 +4,10 @@ import (
 	"context"
 	"log"
 	"os"
+	"os/exec"
 	"testing"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	"github.com/elastic/terraform-provider-elasticstack/provider"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
@@ -25,6 +27,22 @@ func init() {
 }
 
 func PreCheck(t *testing.T) {
+	cassette.Start(t)
+	if mode, _ := cassette.CurrentMode(); mode == cassette.Replay {
+		// The requests are served from the cassette, the endpoints only have to be set.
+		for key, value := range map[string]string{
+			"ELASTICSEARCH_ENDPOINTS": "http://elasticsearch:9200",
+			"ELASTICSEARCH_USERNAME":  "elastic",
+			"ELASTICSEARCH_PASSWORD":  "password",
+			"KIBANA_ENDPOINT":         "http://kibana:5601",
+		} {
+			if _, ok := os.LookupEnv(key); !ok {
+				t.Setenv(key, value)
+			}
+		}
+		return
+	}
+
 	_, elasticsearchEndpointsOk := os.LookupEnv("ELASTICSEARCH_ENDPOINTS")
 	_, kibanaEndpointOk := os.LookupEnv("KIBANA_ENDPOINT")
 	_, userOk := os.LookupEnv("ELASTICSEARCH_USERNAME")
@@ -44,3 +62,14 @@ func PreCheck(t *testing.T) {
 		t.Fatal("ELASTICSEARCH_USERNAME and ELASTICSEARCH_PASSWORD must be set for acceptance tests to run")
 	}
 }
+
+// PreCheckTerraform skips the resource tests running against the fake server in internal/acctest/fake when the
+// Terraform CLI isn't installed, rather than letting resource.UnitTest download it.
+func PreCheckTerraform(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("the Terraform CLI must be installed, or TF_ACC_TERRAFORM_PATH or TF_ACC_TERRAFORM_VERSION set, for the resource unit tests to run")
+	}
+}
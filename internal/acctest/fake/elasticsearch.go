@@ -0,0 +1,358 @@
+package fake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *Server) elasticsearchRoutes() {
+	s.handle(http.MethodGet, "/", s.info)
+	s.handle(http.MethodHead, "/", s.info)
+
+	s.elasticsearchObject("_index_template/{id}", "index_template", func(id string, object map[string]interface{}) interface{} {
+		return map[string]interface{}{"index_templates": []interface{}{map[string]interface{}{"name": id, "index_template": object}}}
+	})
+	s.elasticsearchObject("_component_template/{id}", "component_template", func(id string, object map[string]interface{}) interface{} {
+		return map[string]interface{}{"component_templates": []interface{}{map[string]interface{}{"name": id, "component_template": object}}}
+	})
+	s.elasticsearchObject("_ilm/policy/{id}", "ilm_policy", func(id string, object map[string]interface{}) interface{} {
+		return map[string]interface{}{id: object}
+	})
+	s.elasticsearchObject("_ingest/pipeline/{id}", "ingest_pipeline", func(id string, object map[string]interface{}) interface{} {
+		return map[string]interface{}{id: object}
+	})
+	s.elasticsearchObject("_security/role/{id}", "role", func(id string, object map[string]interface{}) interface{} {
+		return map[string]interface{}{id: object}
+	})
+	s.elasticsearchObject("_security/role_mapping/{id}", "role_mapping", func(id string, object map[string]interface{}) interface{} {
+		return map[string]interface{}{id: object}
+	})
+	s.elasticsearchObject("_security/user/{id}", "user", func(id string, object map[string]interface{}) interface{} {
+		object["username"] = id
+		return map[string]interface{}{id: object}
+	})
+
+	for _, method := range []string{http.MethodPut, http.MethodPost} {
+		s.handle(method, "/_security/user/{id}/_enable", s.setUserEnabled(true))
+		s.handle(method, "/_security/user/{id}/_disable", s.setUserEnabled(false))
+		s.handle(method, "/_security/user/{id}/_password", s.changeUserPassword)
+		s.handle(method, "/_security/api_key", s.createAPIKey("rest"))
+	}
+	s.handle(http.MethodPost, "/_security/cross_cluster/api_key", s.createAPIKey("cross_cluster"))
+	s.handle(http.MethodPut, "/_security/api_key/{id}", s.updateAPIKey("rest"))
+	s.handle(http.MethodPut, "/_security/cross_cluster/api_key/{id}", s.updateAPIKey("cross_cluster"))
+	s.handle(http.MethodGet, "/_security/api_key", s.getAPIKey)
+	s.handle(http.MethodDelete, "/_security/api_key", s.invalidateAPIKey)
+
+	s.handle(http.MethodPost, "/_aliases", s.updateAliases)
+	s.handle(http.MethodGet, "/_alias/{alias}", s.getAlias)
+	s.handle(http.MethodPost, "/_reindex", s.reindex)
+	s.handle(http.MethodGet, "/_tasks/{id}", s.getTask)
+	s.handle(http.MethodPost, "/_tasks/{id}/_cancel", s.cancelTask)
+	s.handle(http.MethodGet, "/_cluster/health/{index}", s.getIndexHealth)
+	s.handle(http.MethodGet, "/_cat/indices", s.catIndices)
+	s.handle(http.MethodGet, "/_cat/indices/{index}", s.catIndices)
+
+	// The index routes are registered last, as {index} matches the paths of the other APIs.
+	s.handle(http.MethodPut, "/{index}", s.createIndex)
+	s.handle(http.MethodGet, "/{index}", s.getIndex)
+	s.handle(http.MethodDelete, "/{index}", s.deleteIndex)
+	s.handle(http.MethodPut, "/{index}/_settings", s.updateIndexSettings)
+	s.handle(http.MethodPut, "/{index}/_mapping", s.updateIndexMappings)
+	s.handle(http.MethodPost, "/{index}/_close", s.setIndexClosed(true))
+	s.handle(http.MethodPost, "/{index}/_open", s.setIndexClosed(false))
+	for _, method := range []string{http.MethodPut, http.MethodPost} {
+		s.handle(method, "/{index}/_alias/{alias}", s.putIndexAlias)
+	}
+	s.handle(http.MethodDelete, "/{index}/_alias/{alias}", s.deleteIndexAlias)
+}
+
+func (s *Server) info(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":         "fake",
+		"cluster_name": "fake",
+		"cluster_uuid": "fake-cluster-uuid",
+		"version": map[string]interface{}{
+			"number":       s.Version,
+			"build_flavor": s.Flavor,
+		},
+		"tagline": "You Know, for Search",
+	})
+}
+
+// elasticsearchObject serves the CRUD APIs of the objects of a collection, which are returned wrapped by response.
+func (s *Server) elasticsearchObject(path, collection string, response func(id string, object map[string]interface{}) interface{}) {
+	put := func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		body, err := readBody(r)
+		if err != nil {
+			elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+			return
+		}
+
+		id := params["id"]
+		_, exists := s.get(collection, id)
+		switch collection {
+		case "index_template", "component_template":
+			if template, ok := body["template"].(map[string]interface{}); ok && template["settings"] != nil {
+				template["settings"] = nestSettings(flattenSettings("", template["settings"], nil))
+			}
+		case "ilm_policy":
+			policy, _ := body["policy"].(map[string]interface{})
+			phases, _ := policy["phases"].(map[string]interface{})
+			for _, phase := range phases {
+				if phase, ok := phase.(map[string]interface{}); ok && phase["min_age"] == nil {
+					phase["min_age"] = "0ms"
+				}
+			}
+			version := 1
+			if existing, ok := s.get(collection, id); ok {
+				version = int(existing["version"].(float64)) + 1
+			}
+			body = map[string]interface{}{"policy": policy, "version": version, "modified_date": now()}
+		case "user":
+			// Passwords are never returned.
+			delete(body, "password")
+			delete(body, "password_hash")
+			if existing, ok := s.get(collection, id); ok {
+				body["enabled"] = existing["enabled"]
+			} else if _, ok := body["enabled"]; !ok {
+				body["enabled"] = true
+			}
+		}
+		s.put(collection, id, body)
+
+		switch collection {
+		case "role", "role_mapping":
+			writeJSON(w, http.StatusOK, map[string]interface{}{collection: map[string]interface{}{"created": !exists}})
+		case "user":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"created": !exists})
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+		}
+	}
+	s.handle(http.MethodPut, path, put)
+	s.handle(http.MethodPost, path, put)
+
+	s.handle(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		object, ok := s.get(collection, params["id"])
+		if !ok {
+			s.elasticsearchNotFound(w, collection, params["id"])
+			return
+		}
+		writeJSON(w, http.StatusOK, response(params["id"], object))
+	})
+
+	// Listing merges the responses of all the objects.
+	s.handle(http.MethodGet, strings.TrimSuffix(path, "/{id}"), func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		all := map[string]interface{}{}
+		for _, id := range s.objectKeys(collection) {
+			object, _ := s.get(collection, id)
+			for key, value := range response(id, object).(map[string]interface{}) {
+				if items, ok := value.([]interface{}); ok {
+					existing, _ := all[key].([]interface{})
+					value = append(existing, items...)
+				}
+				all[key] = value
+			}
+		}
+		writeJSON(w, http.StatusOK, all)
+	})
+
+	s.handle(http.MethodDelete, path, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if !s.delete(collection, params["id"]) {
+			s.elasticsearchNotFound(w, collection, params["id"])
+			return
+		}
+		switch collection {
+		case "role", "role_mapping", "user":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"found": true})
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+		}
+	})
+}
+
+func (s *Server) elasticsearchNotFound(w http.ResponseWriter, collection, id string) {
+	switch collection {
+	case "role", "role_mapping", "user", "ingest_pipeline":
+		// These APIs return an empty body rather than an error.
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{})
+	default:
+		elasticsearchError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("%s [%s] missing", strings.ReplaceAll(collection, "_", " "), id))
+	}
+}
+
+func (s *Server) setUserEnabled(enabled bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		user, ok := s.get("user", params["id"])
+		if !ok {
+			elasticsearchError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("user [%s] not found", params["id"]))
+			return
+		}
+		user["enabled"] = enabled
+		s.put("user", params["id"], user)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}
+}
+
+func (s *Server) changeUserPassword(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.get("user", params["id"]); !ok {
+		elasticsearchError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("user [%s] not found", params["id"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// createAPIKey creates API keys of the given type, `rest` or `cross_cluster`.
+// hasAccess returns whether the access of a cross-cluster API key grants at least one search or replication entry.
+func hasAccess(v interface{}) bool {
+	access, _ := v.(map[string]interface{})
+	search, _ := access["search"].([]interface{})
+	replication, _ := access["replication"].([]interface{})
+	return len(search) > 0 || len(replication) > 0
+}
+
+func (s *Server) createAPIKey(keyType string) handler {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		body, err := readBody(r)
+		if err != nil {
+			elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+			return
+		}
+		name, _ := body["name"].(string)
+		if name == "" {
+			elasticsearchError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: api key name is required;")
+			return
+		}
+		if keyType == "cross_cluster" && !hasAccess(body["access"]) {
+			elasticsearchError(w, http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: must specify non-empty access for either [search] or [replication];")
+			return
+		}
+
+		id := strings.ReplaceAll(s.newID(), "-", "")[:20]
+		key := fmt.Sprintf("fake-api-key-%s", id)
+		apiKey := map[string]interface{}{
+			"id":          id,
+			"name":        name,
+			"creation":    time.Now().UnixMilli(),
+			"invalidated": false,
+			"username":    username,
+			"realm":       "fake",
+			"metadata":    map[string]interface{}{},
+			"type":        keyType,
+		}
+		if access, ok := body["access"]; ok && keyType == "cross_cluster" {
+			apiKey["access"] = access
+		}
+		if metadata, ok := body["metadata"]; ok {
+			apiKey["metadata"] = metadata
+		}
+		if roleDescriptors, ok := body["role_descriptors"]; ok {
+			apiKey["role_descriptors"] = roleDescriptors
+		}
+		response := map[string]interface{}{
+			"id":      id,
+			"name":    name,
+			"api_key": key,
+			"encoded": base64.StdEncoding.EncodeToString([]byte(id + ":" + key)),
+		}
+		if expiration, ok := body["expiration"].(string); ok && expiration != "" {
+			d, err := parseDuration(expiration)
+			if err != nil {
+				elasticsearchError(w, http.StatusBadRequest, "illegal_argument_exception", err.Error())
+				return
+			}
+			apiKey["expiration"] = time.Now().Add(d).UnixMilli()
+			response["expiration"] = apiKey["expiration"]
+		}
+		s.put("api_key", id, apiKey)
+
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+// updateAPIKey updates the API keys of the given type, `rest` or `cross_cluster`.
+func (s *Server) updateAPIKey(keyType string) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		body, err := readBody(r)
+		if err != nil {
+			elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+			return
+		}
+		apiKey, ok := s.get("api_key", params["id"])
+		if !ok || apiKey["invalidated"] == true || apiKey["type"] != keyType {
+			elasticsearchError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("no API key owned by requesting user found for ID [%s]", params["id"]))
+			return
+		}
+
+		updated := false
+		for _, key := range []string{"role_descriptors", "access", "metadata"} {
+			if value, ok := body[key]; ok {
+				updated = updated || fmt.Sprint(apiKey[key]) != fmt.Sprint(value)
+				apiKey[key] = value
+			}
+		}
+		s.put("api_key", params["id"], apiKey)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"updated": updated})
+	}
+}
+
+func (s *Server) getAPIKey(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	id := r.URL.Query().Get("id")
+	apiKey, ok := s.get("api_key", id)
+	if !ok {
+		elasticsearchError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("api key with id [%s] not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"api_keys": []interface{}{apiKey}})
+}
+
+func (s *Server) invalidateAPIKey(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+
+	invalidated := []interface{}{}
+	previouslyInvalidated := []interface{}{}
+	ids, _ := body["ids"].([]interface{})
+	for _, id := range ids {
+		apiKey, ok := s.get("api_key", fmt.Sprint(id))
+		if !ok {
+			continue
+		}
+		if apiKey["invalidated"] == true {
+			previouslyInvalidated = append(previouslyInvalidated, id)
+			continue
+		}
+		apiKey["invalidated"] = true
+		s.put("api_key", fmt.Sprint(id), apiKey)
+		invalidated = append(invalidated, id)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"invalidated_api_keys":            invalidated,
+		"previously_invalidated_api_keys": previouslyInvalidated,
+		"error_count":                     0,
+	})
+}
+
+// parseDuration parses the Elasticsearch time units, e.g. `1d`.
+func parseDuration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		d, err := time.ParseDuration(strings.TrimSuffix(value, "d") + "h")
+		return d * 24, err
+	}
+	return time.ParseDuration(value)
+}
+
+func elasticsearchError(w http.ResponseWriter, status int, errorType, reason string) {
+	cause := map[string]interface{}{"type": errorType, "reason": reason}
+	writeJSON(w, status, map[string]interface{}{
+		"error":  map[string]interface{}{"root_cause": []interface{}{cause}, "type": errorType, "reason": reason},
+		"status": status,
+	})
+}
@@ -0,0 +1,112 @@
+package fake
+
+import (
+	"net/http"
+)
+
+func (s *Server) fleetRoutes() {
+	s.fleetObject("/api/fleet/agent_policies", "agent_policy", func(policy map[string]interface{}) {
+		policy["status"] = "active"
+		policy["is_managed"] = false
+		policy["revision"] = 1
+		policy["updated_by"] = username
+		policy["updated_on"] = now()
+	})
+	s.handle(http.MethodPost, "/api/fleet/agent_policies/delete", s.deleteAgentPolicy)
+
+	s.fleetObject("/api/fleet/outputs", "output", func(output map[string]interface{}) {
+		if _, ok := output["is_default"]; !ok {
+			output["is_default"] = false
+		}
+	})
+	s.fleetObject("/api/fleet/fleet_server_hosts", "fleet_server_host", func(host map[string]interface{}) {
+		if _, ok := host["is_default"]; !ok {
+			host["is_default"] = false
+		}
+	})
+
+	s.handle(http.MethodGet, "/api/fleet/enrollment_api_keys", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"list": []interface{}{}, "items": []interface{}{}, "total": 0, "page": 1, "perPage": 20})
+	})
+}
+
+// fleetObject serves the CRUD APIs of the objects of a collection, which Fleet returns as the `item` of the response.
+// newObject adds the attributes Fleet computes.
+func (s *Server) fleetObject(path, collection string, newObject func(object map[string]interface{})) {
+	s.handle(http.MethodPost, path, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		body, err := readBody(r)
+		if err != nil {
+			kibanaError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		id, _ := body["id"].(string)
+		if id == "" {
+			id = s.newID()
+		}
+		if _, ok := s.get(collection, id); ok {
+			kibanaError(w, http.StatusConflict, "An object with id "+id+" already exists")
+			return
+		}
+
+		body["id"] = id
+		newObject(body)
+		s.put(collection, id, body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"item": body})
+	})
+
+	item := path + "/{id}"
+	s.handle(http.MethodGet, item, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		object, ok := s.get(collection, params["id"])
+		if !ok {
+			kibanaNotFound(w, collection, params["id"])
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"item": object})
+	})
+
+	s.handle(http.MethodPut, item, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		object, ok := s.get(collection, params["id"])
+		if !ok {
+			kibanaNotFound(w, collection, params["id"])
+			return
+		}
+		body, err := readBody(r)
+		if err != nil {
+			kibanaError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for k, v := range body {
+			object[k] = v
+		}
+		if revision, ok := object["revision"].(float64); ok {
+			object["revision"] = revision + 1
+			object["updated_on"] = now()
+		}
+		s.put(collection, params["id"], object)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"item": object})
+	})
+
+	s.handle(http.MethodDelete, item, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if !s.delete(collection, params["id"]) {
+			kibanaNotFound(w, collection, params["id"])
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": params["id"]})
+	})
+}
+
+func (s *Server) deleteAgentPolicy(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		kibanaError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id, _ := body["agentPolicyId"].(string)
+	policy, ok := s.get("agent_policy", id)
+	if !ok {
+		kibanaNotFound(w, "agent_policy", id)
+		return
+	}
+	s.delete("agent_policy", id)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"id": id, "name": policy["name"]})
+}
@@ -0,0 +1,528 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resolveIndex returns the names of the indices matching the name of an index or an alias.
+func (s *Server) resolveIndex(name string) []string {
+	if _, ok := s.objects["index"][name]; ok {
+		return []string{name}
+	}
+	var indices []string
+	for _, index := range s.objectKeys("index") {
+		if aliases, ok := s.objects["index"][index]["aliases"].(map[string]interface{}); ok && aliases[name] != nil {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// searchIndices returns the names of the indices matching a comma-separated list of indices, aliases and wildcard
+// patterns, and the first name without wildcards which doesn't match any index. Wildcards only match the open and
+// visible indices, unless expand_wildcards includes `closed`, `hidden` or `all`. Like Elasticsearch, a missing name
+// is only reported when ignore_unavailable isn't set, and a search without match when allow_no_indices is false.
+func (s *Server) searchIndices(search string, query url.Values) ([]string, string) {
+	expandWildcards := query.Get("expand_wildcards")
+	expand := map[string]bool{}
+	for _, state := range strings.Split(expandWildcards, ",") {
+		expand[state] = true
+	}
+	if expandWildcards == "" {
+		expand["open"] = true
+	}
+	if expand["all"] {
+		expand["open"], expand["closed"], expand["hidden"] = true, true, true
+	}
+
+	matches := map[string]interface{}{}
+	for _, pattern := range strings.Split(search, ",") {
+		if !strings.Contains(pattern, "*") {
+			indices := s.resolveIndex(pattern)
+			if len(indices) == 0 && query.Get("ignore_unavailable") != "true" {
+				return nil, pattern
+			}
+			for _, name := range indices {
+				matches[name] = nil
+			}
+			continue
+		}
+		for _, name := range s.objectKeys("index") {
+			settings, _ := s.objects["index"][name]["settings"].(map[string]interface{})
+			closed := settings["index.verified_before_close"] != nil
+			hidden := settings["index.hidden"] == "true"
+			if ok, _ := path.Match(pattern, name); ok && (closed && expand["closed"] || !closed && expand["open"]) && (!hidden || expand["hidden"]) {
+				matches[name] = nil
+			}
+		}
+	}
+	if len(matches) == 0 && query.Get("allow_no_indices") == "false" {
+		return nil, search
+	}
+	return sortedKeys(matches), ""
+}
+
+func (s *Server) indexNotFound(w http.ResponseWriter, name string) {
+	elasticsearchError(w, http.StatusNotFound, "index_not_found_exception", fmt.Sprintf("no such index [%s]", name))
+}
+
+func (s *Server) createIndex(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	name := params["index"]
+	if len(s.resolveIndex(name)) > 0 {
+		elasticsearchError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s] already exists", name))
+		return
+	}
+
+	index := map[string]interface{}{
+		"aliases":  map[string]interface{}{},
+		"mappings": map[string]interface{}{},
+		"settings": flattenSettings("", body["settings"], map[string]interface{}{
+			"index.number_of_shards":                            "1",
+			"index.number_of_replicas":                          "1",
+			"index.uuid":                                        s.newID(),
+			"index.creation_date":                               strconv.FormatInt(time.Now().UnixMilli(), 10),
+			"index.provided_name":                               name,
+			"index.version.created":                             "8000099",
+			"index.routing.allocation.include._tier_preference": "data_content",
+		}),
+	}
+	if aliases, ok := body["aliases"].(map[string]interface{}); ok {
+		index["aliases"] = aliases
+	}
+	if mappings, ok := body["mappings"].(map[string]interface{}); ok {
+		index["mappings"] = mappings
+	}
+	s.put("index", name, index)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true, "shards_acknowledged": true, "index": name})
+}
+
+func (s *Server) getIndex(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	indices, missing := s.searchIndices(params["index"], r.URL.Query())
+	if missing != "" {
+		s.indexNotFound(w, missing)
+		return
+	}
+	response := map[string]interface{}{}
+	for _, name := range indices {
+		index, _ := s.get("index", name)
+		response[name] = index
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// catIndices lists the indices with their statistics. The fake doesn't store documents, so the indices are empty.
+func (s *Server) catIndices(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	search := params["index"]
+	if search == "" {
+		search = "*"
+	}
+	indices, missing := s.searchIndices(search, r.URL.Query())
+	if missing != "" {
+		s.indexNotFound(w, missing)
+		return
+	}
+	response := []interface{}{}
+	for _, name := range indices {
+		index, _ := s.get("index", name)
+		settings, _ := index["settings"].(map[string]interface{})
+		row := map[string]interface{}{"index": name, "uuid": settings["index.uuid"], "health": "green", "status": "open", "docs.count": "0", "store.size": "225"}
+		if settings["index.verified_before_close"] != nil {
+			row["status"], row["docs.count"], row["store.size"] = "close", nil, nil
+		}
+		response = append(response, row)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) deleteIndex(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	name := params["index"]
+	if _, ok := s.objects["index"][name]; !ok {
+		if len(s.resolveIndex(name)) > 0 {
+			elasticsearchError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("The provided expression [%s] matches an alias, specify the corresponding concrete indices instead.", name))
+			return
+		}
+		s.indexNotFound(w, name)
+		return
+	}
+	s.delete("index", name)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+func (s *Server) updateIndexSettings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	indices := s.resolveIndex(params["index"])
+	if len(indices) == 0 {
+		s.indexNotFound(w, params["index"])
+		return
+	}
+	var static []string
+	for key := range flattenSettings("", body, nil) {
+		if isStaticSetting(key) {
+			static = append(static, key)
+		}
+	}
+	sort.Strings(static)
+	for _, name := range indices {
+		index, _ := s.get("index", name)
+		settings, _ := index["settings"].(map[string]interface{})
+		if len(static) > 0 && settings["index.verified_before_close"] == nil {
+			elasticsearchError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Can't update non dynamic settings [%v] for open indices [[%s]]", static, name))
+			return
+		}
+	}
+	for _, name := range indices {
+		index, _ := s.get("index", name)
+		settings, _ := index["settings"].(map[string]interface{})
+		index["settings"] = flattenSettings("", body, settings)
+		s.put("index", name, index)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+// setIndexClosed closes or opens an index, closed indices being marked by the `index.verified_before_close` setting
+// like in Elasticsearch.
+func (s *Server) setIndexClosed(closed bool) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		indices := s.resolveIndex(params["index"])
+		if len(indices) == 0 {
+			s.indexNotFound(w, params["index"])
+			return
+		}
+		for _, name := range indices {
+			index, _ := s.get("index", name)
+			settings, _ := index["settings"].(map[string]interface{})
+			if closed {
+				settings["index.verified_before_close"] = "true"
+			} else {
+				delete(settings, "index.verified_before_close")
+			}
+			s.put("index", name, index)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true, "shards_acknowledged": true})
+	}
+}
+
+func (s *Server) getIndexHealth(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if len(s.resolveIndex(params["index"])) == 0 {
+		s.indexNotFound(w, params["index"])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cluster_name": "fake", "status": "green", "timed_out": false})
+}
+
+func (s *Server) updateIndexMappings(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	indices := s.resolveIndex(params["index"])
+	if len(indices) == 0 {
+		s.indexNotFound(w, params["index"])
+		return
+	}
+	for _, name := range indices {
+		index, _ := s.get("index", name)
+		mappings, _ := index["mappings"].(map[string]interface{})
+		for key, value := range body {
+			existing, _ := mappings[key].(map[string]interface{})
+			if updated, ok := value.(map[string]interface{}); ok && existing != nil {
+				// New fields are added to the existing ones.
+				for field, mapping := range updated {
+					existing[field] = mapping
+				}
+				continue
+			}
+			mappings[key] = value
+		}
+		index["mappings"] = mappings
+		s.put("index", name, index)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+func (s *Server) putIndexAlias(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	actions := []interface{}{map[string]interface{}{"add": mergeObjects(body, map[string]interface{}{"index": params["index"], "alias": params["alias"]})}}
+	if status, errorType, reason := s.applyAliasActions(actions); status != http.StatusOK {
+		elasticsearchError(w, status, errorType, reason)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+func (s *Server) deleteIndexAlias(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var actions []interface{}
+	for _, alias := range strings.Split(params["alias"], ",") {
+		actions = append(actions, map[string]interface{}{"remove": map[string]interface{}{"index": params["index"], "alias": alias}})
+	}
+	if status, errorType, reason := s.applyAliasActions(actions); status != http.StatusOK {
+		elasticsearchError(w, status, errorType, reason)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+func (s *Server) updateAliases(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	actions, _ := body["actions"].([]interface{})
+	if status, errorType, reason := s.applyAliasActions(actions); status != http.StatusOK {
+		elasticsearchError(w, status, errorType, reason)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+// applyAliasActions applies the actions of the update aliases API atomically: none is applied when one fails.
+func (s *Server) applyAliasActions(actions []interface{}) (int, string, string) {
+	indices := map[string]map[string]interface{}{}
+	for _, name := range s.objectKeys("index") {
+		indices[name], _ = s.get("index", name)
+	}
+
+	for _, a := range actions {
+		action, _ := a.(map[string]interface{})
+		for actionType, p := range action {
+			params, _ := p.(map[string]interface{})
+			name, _ := params["index"].(string)
+			index, ok := indices[name]
+			if !ok {
+				return http.StatusNotFound, "index_not_found_exception", fmt.Sprintf("no such index [%s]", name)
+			}
+			aliases, _ := index["aliases"].(map[string]interface{})
+			alias, _ := params["alias"].(string)
+
+			switch actionType {
+			case "add":
+				if _, ok := indices[alias]; ok {
+					return http.StatusBadRequest, "invalid_alias_name_exception", fmt.Sprintf("Invalid alias name [%s]: an index or data stream exists with the same name as the alias", alias)
+				}
+				definition := map[string]interface{}{}
+				for key, value := range params {
+					if key != "index" && key != "alias" {
+						definition[key] = value
+					}
+				}
+				aliases[alias] = definition
+			case "remove":
+				if _, ok := aliases[alias]; !ok {
+					return http.StatusNotFound, "aliases_not_found_exception", fmt.Sprintf("aliases [%s] missing", alias)
+				}
+				delete(aliases, alias)
+			case "remove_index":
+				delete(indices, name)
+			default:
+				return http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("unsupported action [%s]", actionType)
+			}
+		}
+	}
+
+	for _, alias := range aliasNames(indices) {
+		var writeIndices []string
+		for _, name := range sortedKeys(indices) {
+			aliases, _ := indices[name]["aliases"].(map[string]interface{})
+			if definition, ok := aliases[alias].(map[string]interface{}); ok && definition["is_write_index"] == true {
+				writeIndices = append(writeIndices, name)
+			}
+		}
+		if len(writeIndices) > 1 {
+			return http.StatusBadRequest, "illegal_state_exception", fmt.Sprintf("alias [%s] has more than one write index [%s]", alias, strings.Join(writeIndices, ","))
+		}
+	}
+
+	s.objects["index"] = map[string]map[string]interface{}{}
+	for name, index := range indices {
+		s.put("index", name, index)
+	}
+	return http.StatusOK, "", ""
+}
+
+func (s *Server) getAlias(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	alias := params["alias"]
+	response := map[string]interface{}{}
+	for _, name := range s.objectKeys("index") {
+		index, _ := s.get("index", name)
+		aliases, _ := index["aliases"].(map[string]interface{})
+		if definition, ok := aliases[alias]; ok {
+			response[name] = map[string]interface{}{"aliases": map[string]interface{}{alias: definition}}
+		}
+	}
+	if len(response) == 0 {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": fmt.Sprintf("alias [%s] missing", alias), "status": http.StatusNotFound})
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func aliasNames(indices map[string]map[string]interface{}) []string {
+	names := map[string]interface{}{}
+	for _, index := range indices {
+		aliases, _ := index["aliases"].(map[string]interface{})
+		for alias := range aliases {
+			names[alias] = nil
+		}
+	}
+	return sortedKeys(names)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *Server) reindex(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		elasticsearchError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+	source, _ := body["source"].(map[string]interface{})
+	dest, _ := body["dest"].(map[string]interface{})
+	sourceIndex, _ := source["index"].(string)
+	destIndex, _ := dest["index"].(string)
+	if len(s.resolveIndex(sourceIndex)) == 0 {
+		s.indexNotFound(w, sourceIndex)
+		return
+	}
+	if len(s.resolveIndex(destIndex)) == 0 {
+		s.indexNotFound(w, destIndex)
+		return
+	}
+
+	// The documents aren't stored: the task completes immediately.
+	s.nextID++
+	taskID := fmt.Sprintf("fake-node:%d", s.nextID)
+	s.put("task", taskID, map[string]interface{}{
+		"completed": true,
+		"task":      map[string]interface{}{"node": "fake-node", "id": s.nextID, "action": "indices:data/write/reindex"},
+		"response":  map[string]interface{}{"total": 0, "created": 0, "updated": 0, "failures": []interface{}{}},
+	})
+	if r.URL.Query().Get("wait_for_completion") == "false" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"task": taskID})
+		return
+	}
+	task, _ := s.get("task", taskID)
+	writeJSON(w, http.StatusOK, task["response"])
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	task, ok := s.get("task", params["id"])
+	if !ok {
+		elasticsearchError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("task [%s] isn't running and hasn't stored its results", params["id"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) cancelTask(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if _, ok := s.get("task", params["id"]); !ok {
+		elasticsearchError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("task [%s] is missing", params["id"]))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"nodes": map[string]interface{}{}})
+}
+
+// flattenSettings adds the settings to flat, formatted as the flat settings returned by Elasticsearch: the keys are
+// prefixed with `index.` and the values are strings.
+func flattenSettings(prefix string, settings interface{}, flat map[string]interface{}) map[string]interface{} {
+	if flat == nil {
+		flat = map[string]interface{}{}
+	}
+	switch v := settings.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flattenSettings(strings.TrimPrefix(prefix+"."+key, "."), v[key], flat)
+		}
+	case nil:
+		if prefix != "" {
+			delete(flat, indexSetting(prefix))
+		}
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, value := range v {
+			values[i] = fmt.Sprint(value)
+		}
+		flat[indexSetting(prefix)] = values
+	default:
+		flat[indexSetting(prefix)] = fmt.Sprint(v)
+	}
+	return flat
+}
+
+// nestSettings formats flat settings as the nested settings returned by Elasticsearch, e.g. the settings of templates.
+func nestSettings(flat map[string]interface{}) map[string]interface{} {
+	nested := map[string]interface{}{}
+	for key, value := range flat {
+		parts := strings.Split(key, ".")
+		parent := nested
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := parent[part].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				parent[part] = child
+			}
+			parent = child
+		}
+		parent[parts[len(parts)-1]] = value
+	}
+	return nested
+}
+
+// isStaticSetting returns whether the setting can only be updated on a closed index.
+func isStaticSetting(key string) bool {
+	switch key {
+	case "index.codec", "index.mapping.coerce", "index.load_fixed_bitset_filters_eagerly", "index.shard.check_on_startup":
+		return true
+	}
+	return strings.HasPrefix(key, "index.analysis.") || strings.HasPrefix(key, "index.similarity.")
+}
+
+func indexSetting(key string) string {
+	if strings.HasPrefix(key, "index.") {
+		return key
+	}
+	return "index." + key
+}
+
+func mergeObjects(objects ...map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for _, object := range objects {
+		for key, value := range object {
+			merged[key] = value
+		}
+	}
+	return merged
+}
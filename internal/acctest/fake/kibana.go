@@ -0,0 +1,291 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (s *Server) kibanaRoutes() {
+	s.handle(http.MethodGet, "/api/status", s.status)
+
+	s.handle(http.MethodPost, "/api/spaces/space", s.createSpace)
+	s.handle(http.MethodGet, "/api/spaces/space", s.listSpaces)
+	s.kibanaObject("/api/spaces/space/{id}", "space", nil)
+
+	rules := "/s/{space}/api/alerting/rule"
+	s.handle(http.MethodPost, rules, s.createKibanaObject("rule", newRule))
+	s.handle(http.MethodPost, rules+"/{id}", s.createKibanaObject("rule", newRule))
+	s.kibanaObject(rules+"/{id}", "rule", nil)
+	s.handle(http.MethodGet, "/s/{space}/api/alerting/rules/_find", s.findRules)
+	s.handle(http.MethodPost, rules+"/{id}/_enable", s.setRuleEnabled(true))
+	s.handle(http.MethodPost, rules+"/{id}/_disable", s.setRuleEnabled(false))
+	s.handle(http.MethodPost, rules+"/{id}/_mute_all", s.updateRule("mute_all", true))
+	s.handle(http.MethodPost, rules+"/{id}/_unmute_all", s.updateRule("mute_all", false))
+
+	connectors := "/s/{space}/api/actions/connector"
+	s.handle(http.MethodPost, connectors, s.createKibanaObject("connector", newConnector))
+	s.handle(http.MethodPost, connectors+"/{id}", s.createKibanaObject("connector", newConnector))
+	s.kibanaObject(connectors+"/{id}", "connector", func(object map[string]interface{}) {
+		// Secrets are never returned.
+		delete(object, "secrets")
+	})
+
+	slos := "/s/{space}/api/observability/slos"
+	s.handle(http.MethodPost, slos, s.createKibanaObject("slo", newSlo))
+	s.kibanaObject(slos+"/{id}", "slo", nil)
+}
+
+func (s *Server) status(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":    "fake",
+		"version": map[string]interface{}{"number": s.Version, "build_flavor": s.Flavor},
+		"status":  map[string]interface{}{"overall": map[string]interface{}{"level": "available"}},
+	})
+}
+
+// spaceKey returns the key of an object stored in a Kibana space.
+func spaceKey(params map[string]string) string {
+	space := params["space"]
+	if space == "" {
+		space = "default"
+	}
+	return space + "/" + params["id"]
+}
+
+func (s *Server) createSpace(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := readBody(r)
+	if err != nil {
+		kibanaError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id, _ := body["id"].(string)
+	if id == "" {
+		kibanaError(w, http.StatusBadRequest, "[request body.id]: expected value of type [string] but got [undefined]")
+		return
+	}
+	key := spaceKey(map[string]string{"id": id})
+	if _, ok := s.get("space", key); ok {
+		kibanaError(w, http.StatusConflict, fmt.Sprintf("A space with the identifier %s already exists.", id))
+		return
+	}
+	s.put("space", key, body)
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) listSpaces(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	spaces := []interface{}{}
+	for _, key := range s.objectKeys("space") {
+		space, _ := s.get("space", key)
+		spaces = append(spaces, space)
+	}
+	writeJSON(w, http.StatusOK, spaces)
+}
+
+// objectKeys returns the sorted keys of the objects stored in the collection.
+func (s *Server) objectKeys(collection string) []string {
+	keys := make([]string, 0, len(s.objects[collection]))
+	for key := range s.objects[collection] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// createKibanaObject creates an object from the request body, with the ID from the path when set. newObject adds
+// the attributes Kibana computes.
+func (s *Server) createKibanaObject(collection string, newObject func(id string, body map[string]interface{})) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		body, err := readBody(r)
+		if err != nil {
+			kibanaError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if params["id"] == "" {
+			params["id"] = s.newID()
+		}
+		key := spaceKey(params)
+		if _, ok := s.get(collection, key); ok {
+			kibanaError(w, http.StatusConflict, fmt.Sprintf("Saved object [%s/%s] conflict", collection, params["id"]))
+			return
+		}
+
+		body["id"] = params["id"]
+		newObject(params["id"], body)
+		s.put(collection, key, body)
+
+		response, _ := s.get(collection, key)
+		delete(response, "secrets")
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+// kibanaObject serves the read, update and delete APIs of the objects of a collection. Updates are merged into the
+// stored object. hide removes the attributes Kibana doesn't return.
+func (s *Server) kibanaObject(path, collection string, hide func(object map[string]interface{})) {
+	respond := func(w http.ResponseWriter, object map[string]interface{}) {
+		if hide != nil {
+			hide(object)
+		}
+		writeJSON(w, http.StatusOK, object)
+	}
+
+	s.handle(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		object, ok := s.get(collection, spaceKey(params))
+		if !ok {
+			kibanaNotFound(w, collection, params["id"])
+			return
+		}
+		respond(w, object)
+	})
+
+	s.handle(http.MethodPut, path, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		object, ok := s.get(collection, spaceKey(params))
+		if !ok {
+			kibanaNotFound(w, collection, params["id"])
+			return
+		}
+		body, err := readBody(r)
+		if err != nil {
+			kibanaError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for k, v := range body {
+			object[k] = v
+		}
+		for _, updated := range []string{"updated_at", "updatedAt"} {
+			if _, ok := object[updated]; ok {
+				object[updated] = now()
+			}
+		}
+		if revision, ok := object["revision"].(float64); ok {
+			object["revision"] = revision + 1
+		}
+		s.put(collection, spaceKey(params), object)
+		respond(w, object)
+	})
+
+	s.handle(http.MethodDelete, path, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if !s.delete(collection, spaceKey(params)) {
+			kibanaNotFound(w, collection, params["id"])
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func newRule(id string, rule map[string]interface{}) {
+	if _, ok := rule["enabled"]; !ok {
+		rule["enabled"] = true
+	}
+	for k, v := range map[string]interface{}{
+		"mute_all":          false,
+		"muted_alert_ids":   []interface{}{},
+		"created_by":        username,
+		"updated_by":        username,
+		"api_key_owner":     username,
+		"created_at":        now(),
+		"updated_at":        now(),
+		"scheduled_task_id": id,
+		"execution_status":  map[string]interface{}{"status": "pending", "last_execution_date": now()},
+	} {
+		rule[k] = v
+	}
+	if _, ok := rule["tags"]; !ok {
+		rule["tags"] = []interface{}{}
+	}
+	if _, ok := rule["actions"]; !ok {
+		rule["actions"] = []interface{}{}
+	}
+}
+
+// findRules returns a page of the rules of the space.
+func (s *Server) findRules(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	page, perPage := 1, 10
+	if v, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("per_page")); err == nil && v > 0 {
+		perPage = v
+	}
+
+	rules := []interface{}{}
+	for _, key := range s.objectKeys("rule") {
+		if strings.HasPrefix(key, spaceKey(map[string]string{"space": params["space"]})) {
+			rule, _ := s.get("rule", key)
+			rules = append(rules, rule)
+		}
+	}
+	total := len(rules)
+	start, end := (page-1)*perPage, page*perPage
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": rules[start:end], "page": page, "per_page": perPage, "total": total})
+}
+
+func (s *Server) setRuleEnabled(enabled bool) handler {
+	return s.updateRule("enabled", enabled)
+}
+
+func (s *Server) updateRule(attribute string, value interface{}) handler {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		rule, ok := s.get("rule", spaceKey(params))
+		if !ok {
+			kibanaNotFound(w, "rule", params["id"])
+			return
+		}
+		rule[attribute] = value
+		s.put("rule", spaceKey(params), rule)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newConnector(_ string, connector map[string]interface{}) {
+	connector["is_preconfigured"] = false
+	connector["is_deprecated"] = false
+	connector["is_missing_secrets"] = false
+	if _, ok := connector["config"]; !ok {
+		connector["config"] = map[string]interface{}{}
+	}
+}
+
+func newSlo(_ string, slo map[string]interface{}) {
+	slo["revision"] = 1
+	slo["enabled"] = true
+	slo["createdAt"] = now()
+	slo["updatedAt"] = now()
+	settings, _ := slo["settings"].(map[string]interface{})
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	for _, k := range []string{"syncDelay", "frequency"} {
+		if _, ok := settings[k]; !ok {
+			settings[k] = "1m"
+		}
+	}
+	slo["settings"] = settings
+	slo["summary"] = map[string]interface{}{
+		"status":      "NO_DATA",
+		"sliValue":    -1,
+		"errorBudget": map[string]interface{}{"initial": 0, "consumed": 0, "remaining": 0, "isEstimated": false},
+	}
+}
+
+func kibanaNotFound(w http.ResponseWriter, collection, id string) {
+	kibanaError(w, http.StatusNotFound, fmt.Sprintf("Saved object [%s/%s] not found", collection, id))
+}
+
+func kibanaError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"statusCode": status,
+		"error":      http.StatusText(status),
+		"message":    message,
+	})
+}
@@ -0,0 +1,231 @@
+// Package fake provides an in-memory fake of the Elasticsearch, Kibana and Fleet APIs used by the provider, so that
+// resources can be tested without a running stack.
+//
+// A test starts the server and points the provider and the acceptance testing client at it:
+//
+//	fake.NewServer(t).Setenv(t)
+//
+//	resource.UnitTest(t, resource.TestCase{
+//		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+//		ProtoV5ProviderFactories: acctest.Providers,
+//		...
+//	})
+//
+// The fake only implements the subset of the REST APIs the provider uses: objects can be created, read, updated and
+// deleted, and missing objects are reported with the status codes the provider expects.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
+)
+
+const (
+	// DefaultVersion is the Elasticsearch and Kibana version reported by the server.
+	DefaultVersion = "8.10.0"
+	// DefaultFlavor is the Elasticsearch build flavor reported by the server.
+	DefaultFlavor = "default"
+
+	username = "elastic"
+	password = "changeme"
+)
+
+// Server is an in-memory fake of the Elasticsearch, Kibana and Fleet APIs. Elasticsearch, Kibana and Fleet are all
+// served from the same URL.
+type Server struct {
+	*httptest.Server
+
+	// Version is the Elasticsearch and Kibana version reported by the server.
+	Version string
+	// Flavor is the Elasticsearch build flavor reported by the server, e.g. `serverless`.
+	Flavor string
+
+	mu      sync.Mutex
+	objects map[string]map[string]map[string]interface{}
+	nextID  int
+	routes  []route
+}
+
+// NewServer starts a fake server, which is closed when the test completes.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		Version: DefaultVersion,
+		Flavor:  DefaultFlavor,
+		objects: map[string]map[string]map[string]interface{}{},
+	}
+	s.elasticsearchRoutes()
+	s.kibanaRoutes()
+	s.fleetRoutes()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Setenv points the provider and the acceptance testing client at the server for the duration of the test.
+func (s *Server) Setenv(t *testing.T) {
+	for key, value := range map[string]string{
+		"ELASTICSEARCH_ENDPOINTS":    s.URL,
+		"ELASTICSEARCH_USERNAME":     username,
+		"ELASTICSEARCH_PASSWORD":     password,
+		"ELASTICSEARCH_API_KEY":      "",
+		"ELASTICSEARCH_BEARER_TOKEN": "",
+		"KIBANA_ENDPOINT":            s.URL,
+		"KIBANA_API_KEY":             "",
+		"KIBANA_BEARER_TOKEN":        "",
+		"FLEET_ENDPOINT":             s.URL,
+		"FLEET_API_KEY":              "",
+		// The requests are served by the fake rather than recorded into or replayed from cassettes.
+		cassette.ModeEnvVar: "",
+	} {
+		t.Setenv(key, value)
+	}
+}
+
+// Objects returns the IDs of the objects stored in the given collection, e.g. `index_template`.
+func (s *Server) Objects(collection string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.objects[collection]))
+	for id := range s.objects[collection] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+type handler func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handler
+}
+
+// handle registers the handler of the requests matching the method and path. Path segments formatted as {name} match
+// any value, which is passed to the handler.
+func (s *Server) handle(method, path string, h handler) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(path, "/"), "/"),
+		handler:  h,
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+	if user, pass, ok := r.BasicAuth(); ok && (user != username || pass != password) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"error":  map[string]interface{}{"type": "security_exception", "reason": "unable to authenticate user [" + user + "]"},
+			"status": http.StatusUnauthorized,
+		})
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for _, route := range s.routes {
+		if params, ok := route.match(r.Method, segments); ok {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			route.handler(w, r, params)
+			return
+		}
+	}
+
+	if isKibanaPath(r.URL.Path) {
+		kibanaError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	elasticsearchError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("no handler found for uri [%s] and method [%s]", r.URL.Path, r.Method))
+}
+
+func (r route) match(method string, segments []string) (map[string]string, bool) {
+	if r.method != method || len(r.segments) != len(segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[strings.Trim(segment, "{}")] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func isKibanaPath(path string) bool {
+	return strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/s/")
+}
+
+// get returns a copy of the stored object, so that handlers can decorate it.
+func (s *Server) get(collection, id string) (map[string]interface{}, bool) {
+	object, ok := s.objects[collection][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+func (s *Server) put(collection, id string, object map[string]interface{}) {
+	if s.objects[collection] == nil {
+		s.objects[collection] = map[string]map[string]interface{}{}
+	}
+	s.objects[collection][id] = copyObject(object)
+}
+
+func (s *Server) delete(collection, id string) bool {
+	_, ok := s.objects[collection][id]
+	delete(s.objects[collection], id)
+	return ok
+}
+
+// newID returns a unique ID, formatted as a UUID like the ones Kibana generates.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", s.nextID, s.nextID)
+}
+
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	// A JSON round trip also copies the nested objects.
+	b, _ := json.Marshal(object)
+	var c map[string]interface{}
+	_ = json.Unmarshal(b, &c)
+	return c
+}
+
+func readBody(r *http.Request) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if r.Body == nil {
+		return body, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,263 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disaster37/go-kibana-rest/v8/kbapi"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet/fleetapi"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T) (*fake.Server, *clients.ApiClient) {
+	server := fake.NewServer(t)
+	server.Setenv(t)
+
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+	return server, client
+}
+
+func TestServer_Info(t *testing.T) {
+	server, client := newClient(t)
+	server.Flavor = clients.ServerlessFlavor
+
+	ctx := context.Background()
+	serverVersion, diags := client.ServerVersion(ctx)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, fake.DefaultVersion, serverVersion.String())
+
+	flavor, diags := client.ServerFlavor(ctx)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, clients.ServerlessFlavor, flavor)
+}
+
+func TestServer_Elasticsearch(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	template := &models.IndexTemplate{Name: "logs", IndexPatterns: []string{"logs-*"}, ComposedOf: []string{}}
+	require.False(t, elasticsearch.PutIndexTemplate(ctx, client, template).HasError())
+	gotTemplate, diags := elasticsearch.GetIndexTemplate(ctx, client, "logs")
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, []string{"logs-*"}, gotTemplate.IndexTemplate.IndexPatterns)
+	require.Equal(t, []string{"logs"}, server.Objects("index_template"))
+	require.False(t, elasticsearch.DeleteIndexTemplate(ctx, client, "logs").HasError())
+	gotTemplate, diags = elasticsearch.GetIndexTemplate(ctx, client, "logs")
+	require.False(t, diags.HasError(), diags)
+	require.Nil(t, gotTemplate)
+
+	policy := &models.Policy{Name: "hot", Phases: map[string]models.Phase{
+		"hot": {Actions: map[string]models.Action{"rollover": {"max_age": "1d"}}},
+	}}
+	require.False(t, elasticsearch.PutIlm(ctx, client, policy).HasError())
+	require.False(t, elasticsearch.PutIlm(ctx, client, policy).HasError())
+	gotPolicy, diags := elasticsearch.GetIlm(ctx, client, "hot")
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, gotPolicy.Modified)
+	require.Equal(t, "1d", gotPolicy.Policy.Phases["hot"].Actions["rollover"]["max_age"])
+	require.False(t, elasticsearch.DeleteIlm(ctx, client, "hot").HasError())
+	gotPolicy, diags = elasticsearch.GetIlm(ctx, client, "hot")
+	require.False(t, diags.HasError(), diags)
+	require.Nil(t, gotPolicy)
+
+	name := "pipeline"
+	pipeline := &models.IngestPipeline{Name: name, Processors: []map[string]interface{}{{"set": map[string]interface{}{"field": "a", "value": "b"}}}}
+	require.False(t, elasticsearch.PutIngestPipeline(ctx, client, pipeline).HasError())
+	gotPipeline, diags := elasticsearch.GetIngestPipeline(ctx, client, &name)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, pipeline.Processors, gotPipeline.Processors)
+	require.False(t, elasticsearch.DeleteIngestPipeline(ctx, client, &name).HasError())
+
+	password := "password"
+	user := &models.User{Username: "user", Roles: []string{"viewer"}, Password: &password, Enabled: true}
+	require.False(t, elasticsearch.PutUser(ctx, client, user).HasError())
+	require.False(t, elasticsearch.DisableUser(ctx, client, "user").HasError())
+	gotUser, diags := elasticsearch.GetUser(ctx, client, "user")
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, []string{"viewer"}, gotUser.Roles)
+	require.False(t, gotUser.Enabled)
+	require.Nil(t, gotUser.Password)
+	require.False(t, elasticsearch.DeleteUser(ctx, client, "user").HasError())
+	gotUser, diags = elasticsearch.GetUser(ctx, client, "user")
+	require.False(t, diags.HasError(), diags)
+	require.Nil(t, gotUser)
+
+	apiKey, diags := elasticsearch.PutApiKey(ctx, client, &models.ApiKey{Name: "key", Expiration: "1d"})
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, apiKey.EncodedKey)
+	require.NotZero(t, apiKey.Expiration)
+	gotAPIKey, diags := elasticsearch.GetApiKey(ctx, client, apiKey.Id)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "key", gotAPIKey.Name)
+	require.False(t, elasticsearch.UpdateApiKey(ctx, client, apiKey.Id, &models.ApiKey{
+		RolesDescriptors: map[string]models.Role{"reader": {Cluster: []string{"monitor"}}},
+		Metadata:         map[string]interface{}{"team": "search"},
+	}).HasError())
+	gotAPIKey, diags = elasticsearch.GetApiKey(ctx, client, apiKey.Id)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, []string{"monitor"}, gotAPIKey.RolesDescriptors["reader"].Cluster)
+	require.Equal(t, "search", gotAPIKey.Metadata["team"])
+	require.False(t, elasticsearch.DeleteApiKey(ctx, client, apiKey.Id).HasError())
+	require.True(t, elasticsearch.UpdateApiKey(ctx, client, apiKey.Id, &models.ApiKey{}).HasError())
+	gotAPIKey, diags = elasticsearch.GetApiKey(ctx, client, apiKey.Id)
+	require.False(t, diags.HasError(), diags)
+	require.True(t, gotAPIKey.Invalidated)
+
+	crossClusterKey, diags := elasticsearch.PutCrossClusterApiKey(ctx, client, &models.CrossClusterApiKey{
+		Name:   "remote",
+		Access: models.CrossClusterApiKeyAccess{Search: []models.CrossClusterApiKeyAccessEntry{{Names: []string{"logs-*"}}}},
+	})
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, crossClusterKey.EncodedKey)
+	require.True(t, elasticsearch.UpdateApiKey(ctx, client, crossClusterKey.Id, &models.ApiKey{}).HasError())
+	require.False(t, elasticsearch.UpdateCrossClusterApiKey(ctx, client, crossClusterKey.Id, &models.CrossClusterApiKey{
+		Access: models.CrossClusterApiKeyAccess{Replication: []models.CrossClusterApiKeyAccessEntry{{Names: []string{"archive-*"}}}},
+	}).HasError())
+	gotAPIKey, diags = elasticsearch.GetApiKey(ctx, client, crossClusterKey.Id)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "cross_cluster", gotAPIKey.Type)
+	require.Empty(t, gotAPIKey.Access.Search)
+	require.Equal(t, []string{"archive-*"}, gotAPIKey.Access.Replication[0].Names)
+	_, diags = elasticsearch.PutCrossClusterApiKey(ctx, client, &models.CrossClusterApiKey{Name: "no access"})
+	require.True(t, diags.HasError())
+}
+
+func TestServer_Indices(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	index := &models.Index{Name: "logs", Mappings: map[string]interface{}{"properties": map[string]interface{}{"field": map[string]interface{}{"type": "keyword"}}}}
+	require.False(t, elasticsearch.PutIndex(ctx, client, index, &models.PutIndexParams{}).HasError())
+	require.True(t, elasticsearch.PutIndex(ctx, client, index, &models.PutIndexParams{}).HasError())
+	require.False(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: "logs-000001"}, &models.PutIndexParams{}).HasError())
+	_, diags := elasticsearch.GetIndex(ctx, client, "logs")
+	require.False(t, diags.HasError(), diags)
+
+	taskID, diags := elasticsearch.Reindex(ctx, client, "logs", "logs-000001")
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, taskID)
+	require.False(t, elasticsearch.WaitForTask(ctx, client, taskID).HasError())
+
+	require.False(t, elasticsearch.UpdateAliases(ctx, client, []models.AliasAction{
+		{RemoveIndex: &models.AliasActionParams{Index: "logs"}},
+		{Add: &models.AliasActionParams{Index: "logs-000001", Alias: "logs"}},
+	}).HasError())
+	gotIndex, diags := elasticsearch.GetIndex(ctx, client, "logs")
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "logs-000001", gotIndex.Name)
+	require.Contains(t, gotIndex.Aliases, "logs")
+	require.True(t, elasticsearch.DeleteIndex(ctx, client, "logs").HasError())
+
+	require.True(t, elasticsearch.UpdateIndexSettings(ctx, client, "logs", map[string]interface{}{"codec": "best_compression"}).HasError())
+	require.False(t, elasticsearch.CloseIndex(ctx, client, "logs").HasError())
+	require.False(t, elasticsearch.UpdateIndexSettings(ctx, client, "logs", map[string]interface{}{"codec": "best_compression"}).HasError())
+	require.False(t, elasticsearch.OpenIndex(ctx, client, "logs").HasError())
+	require.False(t, elasticsearch.WaitForIndexHealth(ctx, client, "logs").HasError())
+	gotIndex, diags = elasticsearch.GetIndex(ctx, client, "logs")
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "best_compression", gotIndex.Settings["index.codec"])
+	require.NotContains(t, gotIndex.Settings, "index.verified_before_close")
+
+	require.False(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: "logs-000002"}, &models.PutIndexParams{}).HasError())
+	require.True(t, elasticsearch.UpdateAliases(ctx, client, []models.AliasAction{
+		{Add: &models.AliasActionParams{Index: "logs-000001", Alias: "all-logs", IndexAlias: models.IndexAlias{IsWriteIndex: true}}},
+		{Add: &models.AliasActionParams{Index: "logs-000002", Alias: "all-logs", IndexAlias: models.IndexAlias{IsWriteIndex: true}}},
+	}).HasError())
+	require.False(t, elasticsearch.UpdateAliases(ctx, client, []models.AliasAction{
+		{Add: &models.AliasActionParams{Index: "logs-000001", Alias: "all-logs", IndexAlias: models.IndexAlias{IsWriteIndex: true}}},
+		{Add: &models.AliasActionParams{Index: "logs-000002", Alias: "all-logs", IndexAlias: models.IndexAlias{Routing: "1"}}},
+	}).HasError())
+	members, diags := elasticsearch.GetAlias(ctx, client, "all-logs")
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, members, 2)
+	require.True(t, members["logs-000001"].IsWriteIndex)
+	require.Equal(t, "1", members["logs-000002"].Routing)
+	require.False(t, elasticsearch.DeleteIndex(ctx, client, "logs-000002").HasError())
+	members, diags = elasticsearch.GetAlias(ctx, client, "missing")
+	require.False(t, diags.HasError(), diags)
+	require.Nil(t, members)
+
+	require.False(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: "logs-000003"}, &models.PutIndexParams{}).HasError())
+	require.False(t, elasticsearch.CloseIndex(ctx, client, "logs-000003").HasError())
+	indices, diags := elasticsearch.GetIndices(ctx, client, "logs-*", "open")
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, indices, 1)
+	require.Equal(t, "logs-000001", indices["logs-000001"].Name)
+	catIndices, diags := elasticsearch.CatIndices(ctx, client, "logs-*", "open,closed")
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, catIndices, 2)
+	require.Equal(t, "open", catIndices[0].Status)
+	require.Equal(t, "close", catIndices[1].Status)
+	require.Empty(t, catIndices[1].DocsCount)
+	indices, diags = elasticsearch.GetIndices(ctx, client, "missing", "open")
+	require.False(t, diags.HasError(), diags)
+	require.Empty(t, indices)
+	require.False(t, elasticsearch.DeleteIndex(ctx, client, "logs-000003").HasError())
+
+	require.False(t, elasticsearch.DeleteIndex(ctx, client, "logs-000001").HasError())
+	gotIndex, diags = elasticsearch.GetIndex(ctx, client, "logs")
+	require.False(t, diags.HasError(), diags)
+	require.Nil(t, gotIndex)
+}
+
+func TestServer_Kibana(t *testing.T) {
+	_, client := newClient(t)
+
+	kibana, err := client.GetKibanaClient()
+	require.NoError(t, err)
+
+	_, err = kibana.KibanaSpaces.Create(&kbapi.KibanaSpace{ID: "space", Name: "Space"})
+	require.NoError(t, err)
+	_, err = kibana.KibanaSpaces.Create(&kbapi.KibanaSpace{ID: "space", Name: "Space"})
+	require.Error(t, err)
+
+	_, err = kibana.KibanaSpaces.Update(&kbapi.KibanaSpace{ID: "space", Name: "Updated"})
+	require.NoError(t, err)
+	space, err := kibana.KibanaSpaces.Get("space")
+	require.NoError(t, err)
+	require.Equal(t, "Updated", space.Name)
+
+	require.NoError(t, kibana.KibanaSpaces.Delete("space"))
+	space, err = kibana.KibanaSpaces.Get("space")
+	require.NoError(t, err)
+	require.Nil(t, space)
+}
+
+func TestServer_Fleet(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	fleetClient, err := client.GetFleetClient()
+	require.NoError(t, err)
+
+	hosts := []string{"https://elasticsearch:9200"}
+	output, diags := fleet.CreateOutput(ctx, fleetClient, fleetapi.PostOutputsJSONRequestBody{
+		Name:  "output",
+		Type:  fleetapi.PostOutputsJSONBodyTypeElasticsearch,
+		Hosts: &hosts,
+	})
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, output.Id)
+
+	gotOutput, diags := fleet.ReadOutput(ctx, fleetClient, output.Id)
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "output", gotOutput.Name)
+	require.Equal(t, hosts, *gotOutput.Hosts)
+
+	require.False(t, fleet.DeleteOutput(ctx, fleetClient, output.Id).HasError())
+	gotOutput, diags = fleet.ReadOutput(ctx, fleetClient, output.Id)
+	require.False(t, diags.HasError(), diags)
+	require.Nil(t, gotOutput)
+
+	policy, diags := fleet.CreateAgentPolicy(ctx, fleetClient, fleetapi.AgentPolicyCreateRequest{Name: "policy", Namespace: "default"})
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, []string{policy.Id}, server.Objects("agent_policy"))
+	require.False(t, fleet.DeleteAgentPolicy(ctx, fleetClient, policy.Id).HasError())
+	require.Empty(t, server.Objects("agent_policy"))
+}
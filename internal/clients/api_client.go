@@ -10,6 +10,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/securityprovider"
 	"github.com/disaster37/go-kibana-rest/v8"
@@ -17,9 +18,11 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/generated/alerting"
 	"github.com/elastic/terraform-provider-elasticstack/generated/connectors"
 	"github.com/elastic/terraform-provider-elasticstack/generated/slo"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -63,15 +66,18 @@ func (c *CompositeId) String() string {
 }
 
 type ApiClient struct {
-	elasticsearch            *elasticsearch.Client
-	elasticsearchClusterInfo *models.ClusterInfo
-	kibana                   *kibana.Client
-	alerting                 alerting.AlertingApi
-	connectors               *connectors.Client
-	slo                      slo.SloAPI
-	kibanaConfig             kibana.Config
-	fleet                    *fleet.Client
-	version                  string
+	elasticsearch             *elasticsearch.Client
+	elasticsearchConnectionID string
+	clusterInfo               *clusterInfoCache
+	kibana                    *kibana.Client
+	alerting                  alerting.AlertingApi
+	connectors                *connectors.Client
+	slo                       slo.SloAPI
+	kibanaConfig              KibanaConfig
+	fleet                     *fleet.Client
+	retryPolicy               utils.RetryPolicy
+	esCredentials             BaseConfig
+	version                   string
 }
 
 func NewApiClientFunc(version string) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -81,7 +87,13 @@ func NewApiClientFunc(version string) func(context.Context, *schema.ResourceData
 }
 
 func NewAcceptanceTestingClient() (*ApiClient, error) {
-	ua := buildUserAgent("tf-acceptance-testing")
+	return NewApiClientFromEnv("tf-acceptance-testing")
+}
+
+// NewApiClientFromEnv returns a client configured from the environment variables the provider reads, e.g.
+// `ELASTICSEARCH_ENDPOINTS` or `KIBANA_ENDPOINT`, for use outside of Terraform.
+func NewApiClientFromEnv(version string) (*ApiClient, error) {
+	ua := buildUserAgent(version)
 	baseConfig := BaseConfig{
 		UserAgent: ua,
 		Header:    http.Header{"User-Agent": []string{ua}},
@@ -89,13 +101,18 @@ func NewAcceptanceTestingClient() (*ApiClient, error) {
 		Password:  os.Getenv("ELASTICSEARCH_PASSWORD"),
 	}
 
-	buildEsAccClient := func() (*elasticsearch.Client, error) {
+	buildEsAccClient := func() (*elasticsearch.Client, string, error) {
 		config := elasticsearch.Config{
 			Header: baseConfig.Header,
 		}
 
 		if apiKey := os.Getenv("ELASTICSEARCH_API_KEY"); apiKey != "" {
 			config.APIKey = apiKey
+		} else if bearerToken := os.Getenv("ELASTICSEARCH_BEARER_TOKEN"); bearerToken != "" {
+			config.ServiceToken = bearerToken
+			if clientAuthentication := os.Getenv("ELASTICSEARCH_ES_CLIENT_AUTHENTICATION"); clientAuthentication != "" {
+				config.Header = withEsClientAuthentication(config.Header, clientAuthentication)
+			}
 		} else {
 			config.Username = baseConfig.Username
 			config.Password = baseConfig.Password
@@ -116,40 +133,74 @@ func NewAcceptanceTestingClient() (*ApiClient, error) {
 			}
 		}
 
-		return elasticsearch.NewClient(config)
+		connectionID := esConnectionID(config)
+		transport := config.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		config.Transport = utils.NewRetryTransport("Elasticsearch", utils.DefaultRetryPolicy(), cassette.Transport(transport))
+		config.DisableRetry = true
+
+		es, err := elasticsearch.NewClient(config)
+		return es, connectionID, err
 	}
 
-	kibanaConfig := kibana.Config{
-		Username: baseConfig.Username,
-		Password: baseConfig.Password,
-		Address:  os.Getenv("KIBANA_ENDPOINT"),
+	kibanaConfig := KibanaConfig{
+		Config: kibana.Config{
+			Username: baseConfig.Username,
+			Password: baseConfig.Password,
+			Address:  os.Getenv("KIBANA_ENDPOINT"),
+		},
+		APIKey:      os.Getenv("KIBANA_API_KEY"),
+		BearerToken: os.Getenv("KIBANA_BEARER_TOKEN"),
+		Retry:       utils.DefaultRetryPolicy(),
 	}
 	if insecure := os.Getenv("KIBANA_INSECURE"); insecure != "" {
 		if insecureValue, _ := strconv.ParseBool(insecure); insecureValue {
 			kibanaConfig.DisableVerifySSL = true
 		}
 	}
+	if caCerts := os.Getenv("KIBANA_CA_CERTS"); caCerts != "" {
+		kibanaConfig.CAs = strings.Split(caCerts, ",")
+	}
 
-	es, err := buildEsAccClient()
+	es, connectionID, err := buildEsAccClient()
 	if err != nil {
 		return nil, err
 	}
 
-	kib, err := kibana.NewClient(kibanaConfig)
-	if err != nil {
-		return nil, err
+	kib, diags := buildKibanaClient(kibanaConfig)
+	if diags.HasError() {
+		return nil, fmt.Errorf("cannot create Kibana client: %v", diags)
 	}
 
 	actionConnectors, err := buildConnectorsClient(baseConfig, kibanaConfig)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create Kibana action connectors client: [%w]", err)
 	}
+
+	alertingClient, err := buildAlertingClient(baseConfig, kibanaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create Kibana alerting client: [%w]", err)
+	}
+
+	sloClient, err := buildSloClient(baseConfig, kibanaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create Kibana SLO client: [%w]", err)
+	}
+
 	fleetCfg := fleet.Config{
-		URL:      kibanaConfig.Address,
-		Username: kibanaConfig.Username,
-		Password: kibanaConfig.Password,
-		APIKey:   os.Getenv("FLEET_API_KEY"),
-		Insecure: kibanaConfig.DisableVerifySSL,
+		URL:         kibanaConfig.Address,
+		Username:    kibanaConfig.Username,
+		Password:    kibanaConfig.Password,
+		APIKey:      kibanaConfig.APIKey,
+		BearerToken: kibanaConfig.BearerToken,
+		Insecure:    kibanaConfig.DisableVerifySSL,
+		CACerts:     kibanaConfig.CAs,
+		Retry:       kibanaConfig.Retry,
+	}
+	if v := os.Getenv("FLEET_API_KEY"); v != "" {
+		fleetCfg.APIKey = v
 	}
 	if v := os.Getenv("FLEET_CA_CERTS"); v != "" {
 		fleetCfg.CACerts = strings.Split(os.Getenv("FLEET_CA_CERTS"), ",")
@@ -161,42 +212,88 @@ func NewAcceptanceTestingClient() (*ApiClient, error) {
 	}
 
 	return &ApiClient{
-			elasticsearch: es,
-			kibana:        kib,
-			alerting:      buildAlertingClient(baseConfig, kibanaConfig).AlertingApi,
-			slo:           buildSloClient(baseConfig, kibanaConfig).SloAPI,
-			connectors:    actionConnectors,
-			kibanaConfig:  kibanaConfig,
-			fleet:         fleetClient,
-			version:       "acceptance-testing",
+			elasticsearch:             es,
+			elasticsearchConnectionID: connectionID,
+			clusterInfo:               newClusterInfoCache(),
+			kibana:                    kib,
+			alerting:                  alertingClient.AlertingApi,
+			slo:                       sloClient.SloAPI,
+			connectors:                actionConnectors,
+			kibanaConfig:              kibanaConfig,
+			fleet:                     fleetClient,
+			esCredentials:             BaseConfig{Username: baseConfig.Username, Password: baseConfig.Password},
+			version:                   version,
 		},
 		nil
 }
 
-const esConnectionKey string = "elasticsearch_connection"
+const (
+	esConnectionKey     string = "elasticsearch_connection"
+	kibanaConnectionKey string = "kibana_connection"
+	fleetConnectionKey  string = "fleet_connection"
+)
 
 func NewApiClient(d *schema.ResourceData, meta interface{}) (*ApiClient, diag.Diagnostics) {
-	defaultClient := meta.(*ApiClient)
+	return newResourceApiClient(d, meta.(*ApiClient))
+}
+
+// NewApiClientFromDiff returns the client of a resource while it's being planned.
+func NewApiClientFromDiff(d *schema.ResourceDiff, meta interface{}) (*ApiClient, diag.Diagnostics) {
+	return newResourceApiClient(d, meta.(*ApiClient))
+}
 
-	if _, ok := d.GetOk(esConnectionKey); !ok {
+// newResourceApiClient returns the provider client, with the clients replaced for each connection
+// block the resource defines. A Kibana connection is also used for Fleet, unless the resource
+// defines a Fleet connection as well.
+func newResourceApiClient(d configGetter, defaultClient *ApiClient) (*ApiClient, diag.Diagnostics) {
+	_, hasEsConnection := d.GetOk(esConnectionKey)
+	_, hasKibanaConnection := d.GetOk(kibanaConnectionKey)
+	_, hasFleetConnection := d.GetOk(fleetConnectionKey)
+	if !hasEsConnection && !hasKibanaConnection && !hasFleetConnection {
 		return defaultClient, nil
 	}
 
-	version := defaultClient.version
-	baseConfig := buildBaseConfig(d, version, esConnectionKey)
-
-	esClient, diags := buildEsClient(d, baseConfig, false, esConnectionKey)
+	client := *defaultClient
+	baseConfig, diags := buildBaseConfig(d, client.version, esConnectionKey)
 	if diags.HasError() {
 		return nil, diags
 	}
+	baseConfig.Retry = client.retryPolicy
+	// Like the provider level Kibana connection, the Kibana connection of the resource defaults to the
+	// Elasticsearch credentials, which are the provider ones unless the resource defines its own.
+	if !hasEsConnection {
+		baseConfig.Username = client.esCredentials.Username
+		baseConfig.Password = client.esCredentials.Password
+	}
 
-	return &ApiClient{
-		elasticsearch:            esClient,
-		elasticsearchClusterInfo: defaultClient.elasticsearchClusterInfo,
-		kibana:                   defaultClient.kibana,
-		fleet:                    defaultClient.fleet,
-		version:                  version,
-	}, diags
+	if hasEsConnection {
+		esClient, connectionID, diags := buildEsClient(d, baseConfig, false, esConnectionKey)
+		if diags.HasError() {
+			return nil, diags
+		}
+		client.elasticsearch = esClient
+		client.elasticsearchConnectionID = connectionID
+	}
+
+	if hasKibanaConnection {
+		kibanaConfig, diags := buildKibanaConfig(d, baseConfig, false, kibanaConnectionKey)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if diags := client.setKibanaClients(baseConfig, kibanaConfig); diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	if hasKibanaConnection || hasFleetConnection {
+		fleetClient, diags := buildFleetClient(d, client.kibanaConfig, false, fleetConnectionKey)
+		if diags.HasError() {
+			return nil, diags
+		}
+		client.fleet = fleetClient
+	}
+
+	return &client, nil
 }
 
 func ensureTLSClientConfig(config *elasticsearch.Config) *tls.Config {
@@ -225,6 +322,25 @@ func (a *ApiClient) GetKibanaClient() (*kibana.Client, error) {
 	return a.kibana, nil
 }
 
+// GetKibanaClientWithContext returns a Kibana client sending its requests with the given context, so that they're
+// cancelled with the timeout of the operation. The go-kibana-rest APIs don't take a context.
+func (a *ApiClient) GetKibanaClientWithContext(ctx context.Context) (*kibana.Client, error) {
+	if a.kibana == nil {
+		return nil, errors.New("kibana client not found")
+	}
+
+	// The client shares the transport, and its connections, with the default one.
+	kib, err := newKibanaRestClient(a.kibanaConfig, a.kibana.Client.GetClient().Transport)
+	if err != nil {
+		return nil, err
+	}
+	kib.Client.OnBeforeRequest(func(_ *resty.Client, r *resty.Request) error {
+		r.SetContext(ctx)
+		return nil
+	})
+	return kib, nil
+}
+
 func (a *ApiClient) GetAlertingClient() (alerting.AlertingApi, error) {
 	if a.alerting == nil {
 		return nil, errors.New("alerting client not found")
@@ -257,14 +373,24 @@ func (a *ApiClient) GetFleetClient() (*fleet.Client, error) {
 	return a.fleet, nil
 }
 
+// SetSloAuthContext adds the Kibana basic auth credentials to the context used by the SLO client.
+// Token based credentials are sent as a default header by the client itself, see buildSloClient.
 func (a *ApiClient) SetSloAuthContext(ctx context.Context) context.Context {
+	if _, ok := a.kibanaConfig.authorizationHeader(); ok {
+		return ctx
+	}
 	return context.WithValue(ctx, slo.ContextBasicAuth, slo.BasicAuth{
 		UserName: a.kibanaConfig.Username,
 		Password: a.kibanaConfig.Password,
 	})
 }
 
+// SetAlertingAuthContext adds the Kibana basic auth credentials to the context used by the alerting client.
+// Token based credentials are sent as a default header by the client itself, see buildAlertingClient.
 func (a *ApiClient) SetAlertingAuthContext(ctx context.Context) context.Context {
+	if _, ok := a.kibanaConfig.authorizationHeader(); ok {
+		return ctx
+	}
 	return context.WithValue(ctx, alerting.ContextBasicAuth, alerting.BasicAuth{
 		UserName: a.kibanaConfig.Username,
 		Password: a.kibanaConfig.Password,
@@ -281,8 +407,8 @@ func (a *ApiClient) ID(ctx context.Context, resourceId string) (*CompositeId, di
 }
 
 func (a *ApiClient) serverInfo(ctx context.Context) (*models.ClusterInfo, diag.Diagnostics) {
-	if a.elasticsearchClusterInfo != nil {
-		return a.elasticsearchClusterInfo, nil
+	if info, ok := a.clusterInfo.get(a.elasticsearchConnectionID); ok {
+		return info, nil
 	}
 
 	var diags diag.Diagnostics
@@ -304,7 +430,7 @@ func (a *ApiClient) serverInfo(ctx context.Context) (*models.ClusterInfo, diag.D
 		return nil, diag.FromErr(err)
 	}
 	// cache info
-	a.elasticsearchClusterInfo = &info
+	a.clusterInfo.set(a.elasticsearchConnectionID, &info)
 
 	return &info, diags
 }
@@ -324,6 +450,19 @@ func (a *ApiClient) ServerVersion(ctx context.Context) (*version.Version, diag.D
 	return serverVersion, nil
 }
 
+// ServerlessFlavor is the build flavor reported by Elasticsearch Serverless projects.
+const ServerlessFlavor = "serverless"
+
+// ServerFlavor returns the build flavor of the Elasticsearch server, e.g. `default` or `serverless`.
+func (a *ApiClient) ServerFlavor(ctx context.Context) (string, diag.Diagnostics) {
+	info, diags := a.serverInfo(ctx)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	return info.Version.BuildFlavor, nil
+}
+
 func (a *ApiClient) ClusterID(ctx context.Context) (*string, diag.Diagnostics) {
 	info, diags := a.serverInfo(ctx)
 	if diags.HasError() {
@@ -344,15 +483,75 @@ func (a *ApiClient) ClusterID(ctx context.Context) (*string, diag.Diagnostics) {
 	return nil, diags
 }
 
+// configGetter is satisfied by schema.ResourceData, and allows the client builders to
+// be shared between the SDKv2 and the plugin framework providers.
+type configGetter interface {
+	GetOk(key string) (interface{}, bool)
+}
+
 type BaseConfig struct {
 	Username  string
 	Password  string
+	CloudID   string
 	UserAgent string
 	Header    http.Header
+	Retry     utils.RetryPolicy
+}
+
+// KibanaConfig extends the go-kibana-rest client configuration with the
+// token based authentication methods and TLS options which it doesn't support natively.
+type KibanaConfig struct {
+	kibana.Config
+	APIKey      string
+	BearerToken string
+	CAData      string
+	CertFile    string
+	KeyFile     string
+	CertData    string
+	KeyData     string
+	Retry       utils.RetryPolicy
+}
+
+func (c KibanaConfig) tlsSettings() utils.TLSSettings {
+	return utils.TLSSettings{
+		Insecure: c.DisableVerifySSL,
+		CACerts:  c.CAs,
+		CAData:   c.CAData,
+		CertFile: c.CertFile,
+		KeyFile:  c.KeyFile,
+		CertData: c.CertData,
+		KeyData:  c.KeyData,
+	}
+}
+
+// transport returns the HTTP transport shared by all the Kibana clients.
+func (c KibanaConfig) transport() (http.RoundTripper, error) {
+	tlsConfig, err := c.tlsSettings().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return utils.NewRetryTransport("Kibana", c.Retry, cassette.Transport(transport)), nil
+}
+
+// authorizationHeader returns the Authorization header value to use for token based authentication.
+// It returns false when basic authentication should be used instead.
+func (c KibanaConfig) authorizationHeader() (string, bool) {
+	switch {
+	case c.APIKey != "":
+		return "ApiKey " + c.APIKey, true
+	case c.BearerToken != "":
+		return "Bearer " + c.BearerToken, true
+	default:
+		return "", false
+	}
 }
 
 // Build base config from ES which can be shared for other resources
-func buildBaseConfig(d *schema.ResourceData, version string, esKey string) BaseConfig {
+func buildBaseConfig(d configGetter, version string, esKey string) (BaseConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	baseConfig := BaseConfig{}
 	baseConfig.UserAgent = buildUserAgent(version)
 	baseConfig.Header = http.Header{"User-Agent": []string{baseConfig.UserAgent}}
@@ -367,22 +566,40 @@ func buildBaseConfig(d *schema.ResourceData, version string, esKey string) BaseC
 			if password, ok := config["password"]; ok {
 				baseConfig.Password = password.(string)
 			}
+			if cloudID, ok := config["cloud_id"].(string); ok {
+				baseConfig.CloudID = cloudID
+			}
+			if cloudAuth, ok := config["cloud_auth"].(string); ok && cloudAuth != "" {
+				username, password, err := parseCloudAuth(cloudAuth)
+				if err != nil {
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  "Invalid cloud_auth",
+						Detail:   err.Error(),
+					})
+					return BaseConfig{}, diags
+				}
+				baseConfig.Username = username
+				baseConfig.Password = password
+			}
 		}
 	}
 
-	return baseConfig
+	return baseConfig, diags
 }
 
 func buildUserAgent(version string) string {
 	return fmt.Sprintf("elasticstack-terraform-provider/%s", version)
 }
 
-func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefault bool, key string) (*elasticsearch.Client, diag.Diagnostics) {
+// buildEsClient returns the Elasticsearch client for the connection, along with the ID identifying the
+// connection in the cluster info cache.
+func buildEsClient(d configGetter, baseConfig BaseConfig, useEnvAsDefault bool, key string) (*elasticsearch.Client, string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	esConn, ok := d.GetOk(key)
 	if !ok {
-		return nil, diags
+		return nil, "", diags
 	}
 
 	config := elasticsearch.Config{
@@ -399,6 +616,22 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 			config.APIKey = apikey.(string)
 		}
 
+		if bearerToken, ok := esConfig["bearer_token"].(string); ok {
+			config.ServiceToken = bearerToken
+		}
+
+		if clientAuthentication, ok := esConfig["es_client_authentication"].(string); ok && clientAuthentication != "" {
+			if config.ServiceToken == "" {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Missing JWT",
+					Detail:   fmt.Sprintf("es_client_authentication requires the JWT to be set in bearer_token in the %s block", key),
+				})
+				return nil, "", diags
+			}
+			config.Header = withEsClientAuthentication(config.Header, clientAuthentication)
+		}
+
 		if useEnvAsDefault {
 			if endpoints := os.Getenv("ELASTICSEARCH_ENDPOINTS"); endpoints != "" {
 				var addrs []string
@@ -417,6 +650,27 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 			config.Addresses = addrs
 		}
 
+		if baseConfig.CloudID != "" {
+			if endpoints, ok := esConfig["endpoints"]; ok && len(endpoints.([]interface{})) > 0 {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Conflicting Elasticsearch endpoints",
+					Detail:   fmt.Sprintf("Only one of cloud_id and endpoints can be set in the %s block", key),
+				})
+				return nil, "", diags
+			}
+			cloud, err := parseCloudID(baseConfig.CloudID)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid cloud_id",
+					Detail:   err.Error(),
+				})
+				return nil, "", diags
+			}
+			config.Addresses = []string{cloud.Elasticsearch}
+		}
+
 		if insecure, ok := esConfig["insecure"]; ok && insecure.(bool) {
 			tlsClientConfig := ensureTLSClientConfig(&config)
 			tlsClientConfig.InsecureSkipVerify = true
@@ -430,7 +684,7 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 					Summary:  "Unable to read CA File",
 					Detail:   err.Error(),
 				})
-				return nil, diags
+				return nil, "", diags
 			}
 			config.CACert = caCert
 		}
@@ -447,7 +701,7 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 						Summary:  "Unable to read certificate or key file",
 						Detail:   err.Error(),
 					})
-					return nil, diags
+					return nil, "", diags
 				}
 				tlsClientConfig := ensureTLSClientConfig(&config)
 				tlsClientConfig.Certificates = []tls.Certificate{cert}
@@ -457,7 +711,7 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 					Summary:  "Unable to read key file",
 					Detail:   "Path to key file has not been configured or is empty",
 				})
-				return nil, diags
+				return nil, "", diags
 			}
 		}
 		if certData, ok := esConfig["cert_data"]; ok && certData.(string) != "" {
@@ -469,7 +723,7 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 						Summary:  "Unable to parse certificate or key",
 						Detail:   err.Error(),
 					})
-					return nil, diags
+					return nil, "", diags
 				}
 				tlsClientConfig := ensureTLSClientConfig(&config)
 				tlsClientConfig.Certificates = []tls.Certificate{cert}
@@ -479,11 +733,21 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 					Summary:  "Unable to parse key",
 					Detail:   "Key data has not been configured or is empty",
 				})
-				return nil, diags
+				return nil, "", diags
 			}
 		}
 	}
 
+	connectionID := esConnectionID(config)
+
+	transport := config.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	config.Transport = utils.NewRetryTransport("Elasticsearch", baseConfig.Retry, cassette.Transport(transport))
+	// The retries are handled by the transport above, which doesn't resend the requests the cluster may have processed.
+	config.DisableRetry = true
+
 	if logging.IsDebugOrHigher() {
 		config.EnableDebugLogger = true
 		config.Logger = &debugLogger{Name: "elasticsearch"}
@@ -496,42 +760,78 @@ func buildEsClient(d *schema.ResourceData, baseConfig BaseConfig, useEnvAsDefaul
 			Summary:  "Unable to create Elasticsearch client",
 			Detail:   err.Error(),
 		})
-		return nil, diags
+		return nil, "", diags
 	}
 
-	return es, diags
+	return es, connectionID, diags
+}
+
+// withEsClientAuthentication returns a copy of the header carrying the shared secret of a JWT realm. The header is
+// shared with the other clients, and must not be modified in place.
+func withEsClientAuthentication(header http.Header, clientAuthentication string) http.Header {
+	if !strings.HasPrefix(clientAuthentication, "SharedSecret ") {
+		clientAuthentication = "SharedSecret " + clientAuthentication
+	}
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("ES-Client-Authentication", clientAuthentication)
+	return header
 }
 
-func buildKibanaConfig(d *schema.ResourceData, baseConfig BaseConfig) (kibana.Config, diag.Diagnostics) {
+func buildKibanaConfig(d configGetter, baseConfig BaseConfig, useEnvAsDefault bool, key string) (KibanaConfig, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	kibConn, ok := d.GetOk("kibana")
-	if !ok {
-		return kibana.Config{}, diags
+	kibConn, ok := d.GetOk(key)
+	if !ok && baseConfig.CloudID == "" {
+		return KibanaConfig{}, diags
 	}
 
 	// Use ES details by default
-	config := kibana.Config{
-		Username: baseConfig.Username,
-		Password: baseConfig.Password,
+	config := KibanaConfig{
+		Config: kibana.Config{
+			Username: baseConfig.Username,
+			Password: baseConfig.Password,
+		},
+		Retry: baseConfig.Retry,
+	}
+	// An invalid ES cloud ID is reported when building the ES client.
+	if cloud, err := parseCloudID(baseConfig.CloudID); err == nil {
+		config.Address = cloud.Kibana
 	}
 
 	// if defined, then we only have a single entry
-	if kib := kibConn.([]interface{})[0]; kib != nil {
+	var kib interface{}
+	if ok {
+		kib = kibConn.([]interface{})[0]
+	}
+	if kib != nil {
 		kibConfig := kib.(map[string]interface{})
 
-		if username := os.Getenv("KIBANA_USERNAME"); username != "" {
-			config.Username = strings.TrimSpace(username)
-		}
-		if password := os.Getenv("KIBANA_PASSWORD"); password != "" {
-			config.Password = strings.TrimSpace(password)
-		}
-		if endpoint := os.Getenv("KIBANA_ENDPOINT"); endpoint != "" {
-			config.Address = endpoint
-		}
-		if insecure := os.Getenv("KIBANA_INSECURE"); insecure != "" {
-			if insecureValue, _ := strconv.ParseBool(insecure); insecureValue {
-				config.DisableVerifySSL = true
+		if useEnvAsDefault {
+			if username := os.Getenv("KIBANA_USERNAME"); username != "" {
+				config.Username = strings.TrimSpace(username)
+			}
+			if password := os.Getenv("KIBANA_PASSWORD"); password != "" {
+				config.Password = strings.TrimSpace(password)
+			}
+			if apiKey := os.Getenv("KIBANA_API_KEY"); apiKey != "" {
+				config.APIKey = strings.TrimSpace(apiKey)
+			}
+			if bearerToken := os.Getenv("KIBANA_BEARER_TOKEN"); bearerToken != "" {
+				config.BearerToken = strings.TrimSpace(bearerToken)
+			}
+			if endpoint := os.Getenv("KIBANA_ENDPOINT"); endpoint != "" {
+				config.Address = endpoint
+			}
+			if insecure := os.Getenv("KIBANA_INSECURE"); insecure != "" {
+				if insecureValue, _ := strconv.ParseBool(insecure); insecureValue {
+					config.DisableVerifySSL = true
+				}
+			}
+			if caCerts := os.Getenv("KIBANA_CA_CERTS"); caCerts != "" {
+				config.CAs = strings.Split(caCerts, ",")
 			}
 		}
 
@@ -541,6 +841,36 @@ func buildKibanaConfig(d *schema.ResourceData, baseConfig BaseConfig) (kibana.Co
 		if password, ok := kibConfig["password"]; ok && password != "" {
 			config.Password = password.(string)
 		}
+		if apiKey, ok := kibConfig["api_key"]; ok && apiKey != "" {
+			config.APIKey = apiKey.(string)
+		}
+		if bearerToken, ok := kibConfig["bearer_token"]; ok && bearerToken != "" {
+			config.BearerToken = bearerToken.(string)
+		}
+
+		if cloudID, ok := kibConfig["cloud_id"].(string); ok && cloudID != "" {
+			if endpoints, ok := kibConfig["endpoints"]; ok && len(endpoints.([]interface{})) > 0 {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Conflicting Kibana endpoints",
+					Detail:   fmt.Sprintf("Only one of cloud_id and endpoints can be set in the %s block", key),
+				})
+				return KibanaConfig{}, diags
+			}
+			cloud, err := parseCloudID(cloudID)
+			if err == nil && cloud.Kibana == "" {
+				err = fmt.Errorf("the cloud ID doesn't contain a Kibana endpoint")
+			}
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid cloud_id",
+					Detail:   err.Error(),
+				})
+				return KibanaConfig{}, diags
+			}
+			config.Address = cloud.Kibana
+		}
 
 		if endpoints, ok := kibConfig["endpoints"]; ok && len(endpoints.([]interface{})) > 0 {
 			// We're curently limited by the API to a single endpoint
@@ -552,89 +882,210 @@ func buildKibanaConfig(d *schema.ResourceData, baseConfig BaseConfig) (kibana.Co
 		if insecure, ok := kibConfig["insecure"]; ok && insecure.(bool) {
 			config.DisableVerifySSL = true
 		}
+
+		if caCerts, ok := kibConfig["ca_certs"]; ok && len(caCerts.([]interface{})) > 0 {
+			config.CAs = nil
+			for _, elem := range caCerts.([]interface{}) {
+				if vStr, elemOk := elem.(string); elemOk {
+					config.CAs = append(config.CAs, vStr)
+				}
+			}
+		}
+		if caData, ok := kibConfig["ca_data"]; ok && caData.(string) != "" {
+			config.CAData = caData.(string)
+		}
+		if certFile, ok := kibConfig["cert_file"]; ok && certFile.(string) != "" {
+			config.CertFile = certFile.(string)
+		}
+		if keyFile, ok := kibConfig["key_file"]; ok && keyFile.(string) != "" {
+			config.KeyFile = keyFile.(string)
+		}
+		if certData, ok := kibConfig["cert_data"]; ok && certData.(string) != "" {
+			config.CertData = certData.(string)
+		}
+		if keyData, ok := kibConfig["key_data"]; ok && keyData.(string) != "" {
+			config.KeyData = keyData.(string)
+		}
+	}
+
+	if _, err := config.tlsSettings().ClientConfig(); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to configure TLS for the Kibana connection",
+			Detail:   err.Error(),
+		})
+		return KibanaConfig{}, diags
 	}
 
 	return config, nil
 }
 
-func buildKibanaClient(config kibana.Config) (*kibana.Client, diag.Diagnostics) {
-	kib, err := kibana.NewClient(config)
+func buildKibanaClient(config KibanaConfig) (*kibana.Client, diag.Diagnostics) {
+	transport, err := config.transport()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	kib, err := newKibanaRestClient(config, transport)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	return kib, nil
+}
+
+func newKibanaRestClient(config KibanaConfig, transport http.RoundTripper) (*kibana.Client, error) {
+	// CAs are loaded into the shared transport instead.
+	kibanaConfig := config.Config
+	kibanaConfig.CAs = nil
+	kib, err := kibana.NewClient(kibanaConfig)
+	if err != nil {
+		return nil, err
+	}
+	kib.Client.SetTransport(transport)
+
+	switch {
+	case config.APIKey != "":
+		kib.Client.UserInfo = nil
+		kib.Client.SetAuthScheme("ApiKey").SetAuthToken(config.APIKey)
+	case config.BearerToken != "":
+		kib.Client.UserInfo = nil
+		kib.Client.SetAuthScheme("Bearer").SetAuthToken(config.BearerToken)
+	}
 
 	if logging.IsDebugOrHigher() {
 		kib.Client.SetDebug(true)
+		kib.Client.OnRequestLog(func(l *resty.RequestLog) error {
+			utils.RedactHeaders(l.Header)
+			l.Body = utils.RedactBody(l.Body)
+			return nil
+		})
+		kib.Client.OnResponseLog(func(l *resty.ResponseLog) error {
+			utils.RedactHeaders(l.Header)
+			l.Body = utils.RedactBody(l.Body)
+			return nil
+		})
 	}
 
 	return kib, nil
 }
 
-func buildAlertingClient(baseConfig BaseConfig, config kibana.Config) *alerting.APIClient {
+func buildAlertingClient(baseConfig BaseConfig, config KibanaConfig) (*alerting.APIClient, error) {
+	transport, err := config.transport()
+	if err != nil {
+		return nil, err
+	}
+
+	// The requests are logged by the debug transport, which masks the secrets, rather than by the generated client.
+	if logging.IsDebugOrHigher() {
+		transport = utils.NewDebugTransport("Kibana Alerting", transport)
+	}
+
 	alertingConfig := alerting.Configuration{
-		UserAgent: baseConfig.UserAgent,
+		UserAgent:     baseConfig.UserAgent,
+		DefaultHeader: make(map[string]string),
 		Servers: alerting.ServerConfigurations{
 			{
 				URL: config.Address,
 			},
 		},
-		Debug: logging.IsDebugOrHigher(),
+		HTTPClient: &http.Client{Transport: transport},
+	}
+	if authHeader, ok := config.authorizationHeader(); ok {
+		alertingConfig.AddDefaultHeader("Authorization", authHeader)
 	}
-	return alerting.NewAPIClient(&alertingConfig)
+	return alerting.NewAPIClient(&alertingConfig), nil
 }
 
-func buildConnectorsClient(baseConfig BaseConfig, config kibana.Config) (*connectors.Client, error) {
-	basicAuthProvider, err := securityprovider.NewSecurityProviderBasicAuth(config.Username, config.Password)
+func buildConnectorsClient(baseConfig BaseConfig, config KibanaConfig) (*connectors.Client, error) {
+	var authEditor connectors.RequestEditorFn
+	if authHeader, ok := config.authorizationHeader(); ok {
+		apiKeyProvider, err := securityprovider.NewSecurityProviderApiKey("header", "Authorization", authHeader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create token auth provider: %w", err)
+		}
+		authEditor = apiKeyProvider.Intercept
+	} else {
+		basicAuthProvider, err := securityprovider.NewSecurityProviderBasicAuth(config.Username, config.Password)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create basic auth provider: %w", err)
+		}
+		authEditor = basicAuthProvider.Intercept
+	}
+
+	transport, err := config.transport()
 	if err != nil {
-		return nil, fmt.Errorf("unable to create basic auth provider: %w", err)
+		return nil, err
 	}
 
-	httpClient := &http.Client{}
+	httpClient := &http.Client{Transport: transport}
 
 	if logging.IsDebugOrHigher() {
 		httpClient = &http.Client{
-			Transport: utils.NewDebugTransport("Kibana Action Connectors", http.DefaultTransport),
+			Transport: utils.NewDebugTransport("Kibana Action Connectors", transport),
 		}
 	}
 
 	return connectors.NewClient(
 		config.Address,
-		connectors.WithRequestEditorFn(basicAuthProvider.Intercept),
+		connectors.WithRequestEditorFn(authEditor),
 		connectors.WithHTTPClient(httpClient),
 	)
 }
 
-func buildSloClient(baseConfig BaseConfig, config kibana.Config) *slo.APIClient {
+func buildSloClient(baseConfig BaseConfig, config KibanaConfig) (*slo.APIClient, error) {
+	transport, err := config.transport()
+	if err != nil {
+		return nil, err
+	}
+
+	if logging.IsDebugOrHigher() {
+		transport = utils.NewDebugTransport("Kibana SLOs", transport)
+	}
+
 	sloConfig := slo.Configuration{
-		UserAgent: baseConfig.UserAgent,
+		UserAgent:     baseConfig.UserAgent,
+		DefaultHeader: make(map[string]string),
 		Servers: slo.ServerConfigurations{
 			{
 				URL: config.Address,
 			},
 		},
-		Debug: logging.IsDebugOrHigher(),
+		HTTPClient: &http.Client{Transport: transport},
 	}
-	return slo.NewAPIClient(&sloConfig)
+	if authHeader, ok := config.authorizationHeader(); ok {
+		sloConfig.AddDefaultHeader("Authorization", authHeader)
+	}
+	return slo.NewAPIClient(&sloConfig), nil
 }
 
-func buildFleetClient(d *schema.ResourceData, kibanaCfg kibana.Config) (*fleet.Client, diag.Diagnostics) {
+func buildFleetClient(d configGetter, kibanaCfg KibanaConfig, useEnvAsDefault bool, key string) (*fleet.Client, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	// Order of precedence for config options:
-	// 1 (highest): environment variables
+	// 1 (highest): environment variables, only for the provider configuration
 	// 2: resource config
 	// 3: kibana config
 
-	// Set variables from kibana config.
+	// Set variables from kibana config. The Fleet API is served by Kibana, so the Kibana
+	// endpoint, including one derived from a cloud ID, is also the Fleet endpoint.
 	config := fleet.Config{
-		URL:      kibanaCfg.Address,
-		Username: kibanaCfg.Username,
-		Password: kibanaCfg.Password,
-		Insecure: kibanaCfg.DisableVerifySSL,
+		URL:         kibanaCfg.Address,
+		Username:    kibanaCfg.Username,
+		Password:    kibanaCfg.Password,
+		APIKey:      kibanaCfg.APIKey,
+		BearerToken: kibanaCfg.BearerToken,
+		Insecure:    kibanaCfg.DisableVerifySSL,
+		CACerts:     kibanaCfg.CAs,
+		CAData:      kibanaCfg.CAData,
+		CertFile:    kibanaCfg.CertFile,
+		KeyFile:     kibanaCfg.KeyFile,
+		CertData:    kibanaCfg.CertData,
+		KeyData:     kibanaCfg.KeyData,
+		Retry:       kibanaCfg.Retry,
 	}
 
 	// Set variables from resource config.
-	if fleetDataRaw, ok := d.GetOk("fleet"); ok {
+	if fleetDataRaw, ok := d.GetOk(key); ok {
 		fleetData, ok := fleetDataRaw.([]interface{})[0].(map[string]any)
 		if !ok {
 			diags = append(diags, diag.Diagnostic{
@@ -648,7 +1099,10 @@ func buildFleetClient(d *schema.ResourceData, kibanaCfg kibana.Config) (*fleet.C
 			config.URL = v
 		}
 		if v, ok := fleetData["username"].(string); ok && v != "" {
+			// Explicit Fleet credentials take precedence over any token inherited from the Kibana config.
 			config.Username = v
+			config.APIKey = ""
+			config.BearerToken = ""
 		}
 		if v, ok := fleetData["password"].(string); ok && v != "" {
 			config.Password = v
@@ -657,31 +1111,56 @@ func buildFleetClient(d *schema.ResourceData, kibanaCfg kibana.Config) (*fleet.C
 			config.APIKey = v
 		}
 		if v, ok := fleetData["ca_certs"].([]interface{}); ok && len(v) > 0 {
+			config.CACerts = nil
 			for _, elem := range v {
 				if vStr, elemOk := elem.(string); elemOk {
 					config.CACerts = append(config.CACerts, vStr)
 				}
 			}
 		}
+		if v, ok := fleetData["ca_data"].(string); ok && v != "" {
+			config.CAData = v
+		}
+		if v, ok := fleetData["cert_file"].(string); ok && v != "" {
+			config.CertFile = v
+			config.KeyFile = ""
+			config.CertData = ""
+			config.KeyData = ""
+		}
+		if v, ok := fleetData["key_file"].(string); ok && v != "" {
+			config.KeyFile = v
+		}
+		if v, ok := fleetData["cert_data"].(string); ok && v != "" {
+			config.CertFile = ""
+			config.KeyFile = ""
+			config.CertData = v
+		}
+		if v, ok := fleetData["key_data"].(string); ok && v != "" {
+			config.KeyData = v
+		}
 		if v, ok := fleetData["insecure"].(bool); ok {
 			config.Insecure = v
 		}
 	}
 
-	if v := os.Getenv("FLEET_ENDPOINT"); v != "" {
-		config.URL = v
-	}
-	if v := os.Getenv("FLEET_USERNAME"); v != "" {
-		config.Username = v
-	}
-	if v := os.Getenv("FLEET_PASSWORD"); v != "" {
-		config.Password = v
-	}
-	if v := os.Getenv("FLEET_API_KEY"); v != "" {
-		config.APIKey = v
-	}
-	if v := os.Getenv("FLEET_CA_CERTS"); v != "" {
-		config.CACerts = strings.Split(v, ",")
+	if useEnvAsDefault {
+		if v := os.Getenv("FLEET_ENDPOINT"); v != "" {
+			config.URL = v
+		}
+		if v := os.Getenv("FLEET_USERNAME"); v != "" {
+			config.Username = v
+			config.APIKey = ""
+			config.BearerToken = ""
+		}
+		if v := os.Getenv("FLEET_PASSWORD"); v != "" {
+			config.Password = v
+		}
+		if v := os.Getenv("FLEET_API_KEY"); v != "" {
+			config.APIKey = v
+		}
+		if v := os.Getenv("FLEET_CA_CERTS"); v != "" {
+			config.CACerts = strings.Split(v, ",")
+		}
 	}
 
 	client, err := fleet.NewClient(config)
@@ -699,45 +1178,121 @@ func buildFleetClient(d *schema.ResourceData, kibanaCfg kibana.Config) (*fleet.C
 
 const esKey string = "elasticsearch"
 
-func newApiClient(d *schema.ResourceData, version string) (*ApiClient, diag.Diagnostics) {
-	baseConfig := buildBaseConfig(d, version, esKey)
-	kibanaConfig, diags := buildKibanaConfig(d, baseConfig)
+func newApiClient(d configGetter, version string) (*ApiClient, diag.Diagnostics) {
+	baseConfig, diags := buildBaseConfig(d, version, esKey)
+	if diags.HasError() {
+		return nil, diags
+	}
+	retryPolicy, diags := buildRetryPolicy(d)
 	if diags.HasError() {
 		return nil, diags
 	}
+	baseConfig.Retry = retryPolicy
 
-	esClient, diags := buildEsClient(d, baseConfig, true, esKey)
+	esClient, connectionID, diags := buildEsClient(d, baseConfig, true, esKey)
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	kibanaClient, diags := buildKibanaClient(kibanaConfig)
+	client := &ApiClient{
+		elasticsearch:             esClient,
+		elasticsearchConnectionID: connectionID,
+		clusterInfo:               newClusterInfoCache(),
+		retryPolicy:               retryPolicy,
+		esCredentials:             BaseConfig{Username: baseConfig.Username, Password: baseConfig.Password},
+		version:                   version,
+	}
+
+	kibanaConfig, diags := buildKibanaConfig(d, baseConfig, true, "kibana")
+	if diags.HasError() {
+		return nil, diags
+	}
+	if diags := client.setKibanaClients(baseConfig, kibanaConfig); diags.HasError() {
+		return nil, diags
+	}
+
+	client.fleet, diags = buildFleetClient(d, kibanaConfig, true, "fleet")
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	alertingClient := buildAlertingClient(baseConfig, kibanaConfig)
-	sloClient := buildSloClient(baseConfig, kibanaConfig)
+	return client, nil
+}
+
+// setKibanaClients builds every client talking to the Kibana instance described by the config.
+func (a *ApiClient) setKibanaClients(baseConfig BaseConfig, kibanaConfig KibanaConfig) diag.Diagnostics {
+	kibanaClient, diags := buildKibanaClient(kibanaConfig)
+	if diags.HasError() {
+		return diags
+	}
+
+	alertingClient, err := buildAlertingClient(baseConfig, kibanaConfig)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cannot create Kibana alerting client: [%w]", err))
+	}
+
+	sloClient, err := buildSloClient(baseConfig, kibanaConfig)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cannot create Kibana SLO client: [%w]", err))
+	}
 
 	connectorsClient, err := buildConnectorsClient(baseConfig, kibanaConfig)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("cannot create Kibana connectors client: [%w]", err))
+		return diag.FromErr(fmt.Errorf("cannot create Kibana connectors client: [%w]", err))
 	}
 
-	fleetClient, diags := buildFleetClient(d, kibanaConfig)
-	if diags.HasError() {
-		return nil, diags
+	a.kibana = kibanaClient
+	a.kibanaConfig = kibanaConfig
+	a.alerting = alertingClient.AlertingApi
+	a.slo = sloClient.SloAPI
+	a.connectors = connectorsClient
+	return nil
+}
+
+func buildRetryPolicy(d configGetter) (utils.RetryPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	policy := utils.DefaultRetryPolicy()
+
+	retryConn, ok := d.GetOk("retry")
+	if !ok {
+		return policy, diags
+	}
+	retryConfig, ok := retryConn.([]interface{})[0].(map[string]interface{})
+	if !ok {
+		return policy, diags
 	}
 
-	return &ApiClient{
-		elasticsearch:            esClient,
-		elasticsearchClusterInfo: nil,
-		kibana:                   kibanaClient,
-		kibanaConfig:             kibanaConfig,
-		alerting:                 alertingClient.AlertingApi,
-		connectors:               connectorsClient,
-		slo:                      sloClient.SloAPI,
-		fleet:                    fleetClient,
-		version:                  version,
-	}, nil
+	if maxRetries, ok := retryConfig["max_retries"].(int); ok {
+		policy.MaxRetries = maxRetries
+	}
+	for key, target := range map[string]*time.Duration{"min_backoff": &policy.MinBackoff, "max_backoff": &policy.MaxBackoff} {
+		if value, ok := retryConfig[key].(string); ok && value != "" {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid retry configuration",
+					Detail:   fmt.Sprintf("%s contains an invalid duration: %s", key, err),
+				})
+				return policy, diags
+			}
+			*target = duration
+		}
+	}
+	if policy.MinBackoff > policy.MaxBackoff {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid retry configuration",
+			Detail:   fmt.Sprintf("min_backoff [%s] must not be greater than max_backoff [%s]", policy.MinBackoff, policy.MaxBackoff),
+		})
+		return policy, diags
+	}
+	if statusCodes, ok := retryConfig["retryable_status_codes"].([]interface{}); ok && len(statusCodes) > 0 {
+		policy.RetryableStatusCodes = nil
+		for _, code := range statusCodes {
+			policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, code.(int))
+		}
+	}
+
+	return policy, diags
 }
@@ -0,0 +1,665 @@
+package clients
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disaster37/go-kibana-rest/v8"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_kibanaClientsAuthentication(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         KibanaConfig
+		expectedHeader string
+	}{
+		{
+			name: "basic auth is used when no token is configured",
+			config: KibanaConfig{
+				Config: kibana.Config{Username: "elastic", Password: "changeme"},
+			},
+			expectedHeader: "Basic ZWxhc3RpYzpjaGFuZ2VtZQ==",
+		},
+		{
+			name: "api key takes precedence over basic auth",
+			config: KibanaConfig{
+				Config: kibana.Config{Username: "elastic", Password: "changeme"},
+				APIKey: "encoded-api-key",
+			},
+			expectedHeader: "ApiKey encoded-api-key",
+		},
+		{
+			name: "bearer token takes precedence over basic auth",
+			config: KibanaConfig{
+				Config:      kibana.Config{Username: "elastic", Password: "changeme"},
+				BearerToken: "service-token",
+			},
+			expectedHeader: "Bearer service-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			headers := map[string][]string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				headers[r.URL.Path] = r.Header.Values("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			tt.config.Address = server.URL
+			callKibanaClients(t, tt.config)
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Len(t, headers, 5)
+			for path, values := range headers {
+				require.Equal(t, []string{tt.expectedHeader}, values, "unexpected Authorization header for %s", path)
+			}
+		})
+	}
+}
+
+func Test_kibanaClientsMutualTLS(t *testing.T) {
+	ca, caPEM := generateTestCertificate(t, "test-ca", nil)
+	serverCert, _ := generateTestCertificate(t, "127.0.0.1", &ca)
+	clientCert, clientPEM := generateTestCertificate(t, "client", &ca)
+	clientKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(clientCert.PrivateKey.(*rsa.PrivateKey))})
+
+	caPool := x509.NewCertPool()
+	caPool.AppendCertsFromPEM(caPEM)
+
+	var mu sync.Mutex
+	clientCNs := map[string]string{}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		clientCNs[r.URL.Path] = r.TLS.PeerCertificates[0].Subject.CommonName
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	config := KibanaConfig{
+		Config:   kibana.Config{Address: server.URL, Username: "elastic", Password: "changeme"},
+		CAData:   string(caPEM),
+		CertData: string(clientPEM),
+		KeyData:  string(clientKeyPEM),
+	}
+	callKibanaClients(t, config)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, clientCNs, 5)
+	for path, cn := range clientCNs {
+		require.Equal(t, "client", cn, "unexpected client certificate for %s", path)
+	}
+}
+
+func Test_kibanaClientsRetry(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		if attempts[r.URL.Path] == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := KibanaConfig{
+		Config: kibana.Config{Address: server.URL, Username: "elastic", Password: "changeme"},
+		Retry: utils.RetryPolicy{
+			MaxRetries:           1,
+			MinBackoff:           time.Millisecond,
+			MaxBackoff:           time.Millisecond,
+			RetryableStatusCodes: utils.DefaultRetryableStatusCodes(),
+		},
+	}
+	callKibanaClients(t, config)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, attempts, 5)
+	for path, count := range attempts {
+		require.Equal(t, 2, count, "unexpected number of attempts for %s", path)
+	}
+}
+
+func Test_kibanaClientWithContext(t *testing.T) {
+	var mu sync.Mutex
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		authorization = r.Header.Get("Authorization")
+		mu.Unlock()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	t.Setenv("KIBANA_ENDPOINT", server.URL)
+	t.Setenv("KIBANA_API_KEY", "api-key")
+	client, err := NewApiClientFromEnv("test")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	kib, err := client.GetKibanaClientWithContext(ctx)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = kib.KibanaSpaces.Get("space")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, "ApiKey api-key", authorization)
+}
+
+func Test_esClientRetry(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		// Answer the client product check.
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.0"}}`))
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	baseConfig := BaseConfig{
+		Retry: utils.RetryPolicy{
+			MaxRetries:           2,
+			MinBackoff:           time.Millisecond,
+			MaxBackoff:           time.Millisecond,
+			RetryableStatusCodes: utils.DefaultRetryableStatusCodes(),
+		},
+	}
+	config := frameworkConfig{
+		esKey: []interface{}{map[string]interface{}{"endpoints": []interface{}{server.URL}}},
+	}
+	es, _, diags := buildEsClient(config, baseConfig, false, esKey)
+	require.False(t, diags.HasError())
+
+	res, err := es.Cluster.Health()
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 3, attempts)
+}
+
+func Test_esClientAuthentication(t *testing.T) {
+	tests := []struct {
+		name                         string
+		esConfig                     map[string]interface{}
+		expectedAuthorization        string
+		expectedClientAuthentication string
+		expectedError                string
+	}{
+		{
+			name:                  "bearer token",
+			esConfig:              map[string]interface{}{"bearer_token": "service-token"},
+			expectedAuthorization: "Bearer service-token",
+		},
+		{
+			name:                         "JWT with a shared secret",
+			esConfig:                     map[string]interface{}{"bearer_token": "jwt", "es_client_authentication": "secret"},
+			expectedAuthorization:        "Bearer jwt",
+			expectedClientAuthentication: "SharedSecret secret",
+		},
+		{
+			name:                         "prefixed shared secret",
+			esConfig:                     map[string]interface{}{"bearer_token": "jwt", "es_client_authentication": "SharedSecret secret"},
+			expectedAuthorization:        "Bearer jwt",
+			expectedClientAuthentication: "SharedSecret secret",
+		},
+		{
+			name:          "shared secret without a JWT",
+			esConfig:      map[string]interface{}{"es_client_authentication": "secret"},
+			expectedError: "es_client_authentication requires the JWT to be set in bearer_token in the elasticsearch block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var headers http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("X-Elastic-Product", "Elasticsearch")
+				if r.URL.Path == "/" {
+					_, _ = w.Write([]byte(`{"version":{"number":"8.0.0"}}`))
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				headers = r.Header.Clone()
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			tt.esConfig["endpoints"] = []interface{}{server.URL}
+			baseConfig := BaseConfig{Header: http.Header{"User-Agent": []string{"test"}}}
+			es, _, diags := buildEsClient(frameworkConfig{esKey: []interface{}{tt.esConfig}}, baseConfig, false, esKey)
+			if tt.expectedError != "" {
+				require.True(t, diags.HasError())
+				require.Equal(t, tt.expectedError, diags[0].Detail)
+				return
+			}
+			require.False(t, diags.HasError())
+
+			res, err := es.Cluster.Health()
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Equal(t, tt.expectedAuthorization, headers.Get("Authorization"))
+			require.Equal(t, tt.expectedClientAuthentication, headers.Get("ES-Client-Authentication"))
+			require.Equal(t, http.Header{"User-Agent": []string{"test"}}, baseConfig.Header, "the shared header should not be modified")
+		})
+	}
+}
+
+func Test_esClientAuthenticationFromEnv(t *testing.T) {
+	var mu sync.Mutex
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		mu.Lock()
+		defer mu.Unlock()
+		headers = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("ELASTICSEARCH_ENDPOINTS", server.URL)
+	t.Setenv("ELASTICSEARCH_BEARER_TOKEN", "jwt")
+	t.Setenv("ELASTICSEARCH_ES_CLIENT_AUTHENTICATION", "secret")
+	client, err := NewApiClientFromEnv("test")
+	require.NoError(t, err)
+	es, err := client.GetESClient()
+	require.NoError(t, err)
+
+	res, err := es.Cluster.Health()
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, "Bearer jwt", headers.Get("Authorization"))
+	require.Equal(t, "SharedSecret secret", headers.Get("ES-Client-Authentication"))
+}
+
+func Test_clusterInfoPerConnection(t *testing.T) {
+	newCluster := func(name, version string) *httptest.Server {
+		var mu sync.Mutex
+		requests := 0
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests++
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			// A new UUID on every request shows whether the info was served from the cache.
+			_, _ = fmt.Fprintf(w, `{"cluster_name":%q,"cluster_uuid":"%s-%d","version":{"number":%q}}`, name, name, requests, version)
+		}))
+	}
+	clusterA := newCluster("cluster-a", "8.0.0")
+	defer clusterA.Close()
+	clusterB := newCluster("cluster-b", "7.17.0")
+	defer clusterB.Close()
+
+	ctx := context.Background()
+	connection := func(endpoint, username string) frameworkConfig {
+		return frameworkConfig{esConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{endpoint},
+			"username":  username,
+			"password":  "changeme",
+		}}}
+	}
+	clusterInfo := func(client *ApiClient) (string, string) {
+		id, diags := client.ClusterID(ctx)
+		require.False(t, diags.HasError())
+		v, diags := client.ServerVersion(ctx)
+		require.False(t, diags.HasError())
+		return *id, v.String()
+	}
+
+	t.Setenv("ELASTICSEARCH_ENDPOINTS", "")
+	defaultClient, diags := newApiClient(frameworkConfig{
+		esKey: []interface{}{map[string]interface{}{"endpoints": []interface{}{clusterA.URL}}},
+	}, "test")
+	require.False(t, diags.HasError())
+	defaultID, defaultVersion := clusterInfo(defaultClient)
+	require.Contains(t, defaultID, "cluster-a-")
+	require.Equal(t, "8.0.0", defaultVersion)
+
+	resourceClient, diags := newResourceApiClient(connection(clusterB.URL, "elastic"), defaultClient)
+	require.False(t, diags.HasError())
+	resourceID, resourceVersion := clusterInfo(resourceClient)
+	require.Contains(t, resourceID, "cluster-b-")
+	require.Equal(t, "7.17.0", resourceVersion)
+
+	// Clients built later for the same connection share its cache entry.
+	resourceClient, diags = newResourceApiClient(connection(clusterB.URL, "elastic"), defaultClient)
+	require.False(t, diags.HasError())
+	id, _ := clusterInfo(resourceClient)
+	require.Equal(t, resourceID, id)
+
+	// Different credentials make a different connection.
+	resourceClient, diags = newResourceApiClient(connection(clusterB.URL, "other"), defaultClient)
+	require.False(t, diags.HasError())
+	id, _ = clusterInfo(resourceClient)
+	require.Contains(t, id, "cluster-b-")
+	require.NotEqual(t, resourceID, id)
+
+	id, version := clusterInfo(defaultClient)
+	require.Equal(t, defaultID, id)
+	require.Equal(t, defaultVersion, version)
+}
+
+func Test_buildRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         map[string]interface{}
+		expectedPolicy utils.RetryPolicy
+		expectedError  string
+	}{
+		{
+			name:           "uses the default policy when the block is not configured",
+			config:         map[string]interface{}{},
+			expectedPolicy: utils.DefaultRetryPolicy(),
+		},
+		{
+			name: "reads the configured policy",
+			config: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{
+					"max_retries":            5,
+					"min_backoff":            "500ms",
+					"max_backoff":            "1m",
+					"retryable_status_codes": []interface{}{429},
+				}},
+			},
+			expectedPolicy: utils.RetryPolicy{
+				MaxRetries:           5,
+				MinBackoff:           500 * time.Millisecond,
+				MaxBackoff:           time.Minute,
+				RetryableStatusCodes: []int{429},
+			},
+		},
+		{
+			name: "rejects invalid durations",
+			config: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{"min_backoff": "soon"}},
+			},
+			expectedError: "min_backoff contains an invalid duration",
+		},
+		{
+			name: "rejects a minimum backoff greater than the maximum",
+			config: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{"min_backoff": "1m", "max_backoff": "1s"}},
+			},
+			expectedError: "must not be greater than max_backoff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, diags := buildRetryPolicy(frameworkConfig(tt.config))
+			if tt.expectedError != "" {
+				require.True(t, diags.HasError())
+				require.Contains(t, diags[0].Detail, tt.expectedError)
+				return
+			}
+			require.False(t, diags.HasError())
+			require.Equal(t, tt.expectedPolicy, policy)
+		})
+	}
+}
+
+func Test_newResourceApiClient(t *testing.T) {
+	newServer := func() (*httptest.Server, func() map[string]string) {
+		var mu sync.Mutex
+		requests := map[string]string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests[r.URL.Path] = r.Header.Get("Authorization")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		return server, func() map[string]string {
+			mu.Lock()
+			defer mu.Unlock()
+			return requests
+		}
+	}
+
+	providerServer, providerRequests := newServer()
+	defer providerServer.Close()
+	resourceServer, resourceRequests := newServer()
+	defer resourceServer.Close()
+
+	// Environment variables only apply to the provider configuration.
+	t.Setenv("KIBANA_ENDPOINT", providerServer.URL)
+	t.Setenv("FLEET_ENDPOINT", "")
+	defaultClient, diags := newApiClient(frameworkConfig{
+		esKey:    []interface{}{map[string]interface{}{"username": "elastic", "password": "es-password"}},
+		"kibana": []interface{}{map[string]interface{}{"username": "elastic", "password": "changeme"}},
+	}, "test")
+	require.False(t, diags.HasError())
+
+	client, diags := newResourceApiClient(frameworkConfig{}, defaultClient)
+	require.False(t, diags.HasError())
+	require.Same(t, defaultClient, client)
+
+	client, diags = newResourceApiClient(frameworkConfig{
+		kibanaConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{resourceServer.URL},
+			"api_key":   "resource-api-key",
+		}},
+	}, defaultClient)
+	require.False(t, diags.HasError())
+	callApiClient(t, client)
+	require.Empty(t, providerRequests())
+	require.Len(t, resourceRequests(), 5)
+	for path, header := range resourceRequests() {
+		require.Equal(t, "ApiKey resource-api-key", header, "unexpected Authorization header for %s", path)
+	}
+
+	// Without credentials, the Kibana connection uses the Elasticsearch ones.
+	client, diags = newResourceApiClient(frameworkConfig{
+		kibanaConnectionKey: []interface{}{map[string]interface{}{
+			"endpoints": []interface{}{resourceServer.URL},
+		}},
+	}, defaultClient)
+	require.False(t, diags.HasError())
+	callApiClient(t, client)
+	require.Empty(t, providerRequests())
+	for path, header := range resourceRequests() {
+		require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("elastic:es-password")), header, "unexpected Authorization header for %s", path)
+	}
+
+	client, diags = newResourceApiClient(frameworkConfig{
+		fleetConnectionKey: []interface{}{map[string]interface{}{
+			"endpoint": providerServer.URL + "/fleet",
+			"api_key":  "fleet-api-key",
+		}},
+	}, defaultClient)
+	require.False(t, diags.HasError())
+	fleetClient, err := client.GetFleetClient()
+	require.NoError(t, err)
+	_, _ = fleetClient.API.GetEnrollmentApiKeys(context.Background())
+	require.Equal(t, map[string]string{"/fleet/api/fleet/enrollment_api_keys": "ApiKey fleet-api-key"}, providerRequests())
+	require.Same(t, defaultClient.kibana, client.kibana)
+}
+
+// callApiClient sends a single request through each of the Kibana and Fleet clients held by the API client.
+func callApiClient(t *testing.T, client *ApiClient) {
+	ctx := context.Background()
+
+	kib, err := client.GetKibanaClient()
+	require.NoError(t, err)
+	_, _ = kib.KibanaSpaces.Get("space")
+
+	alertingClient, err := client.GetAlertingClient()
+	require.NoError(t, err)
+	_, _, _ = alertingClient.GetRule(client.SetAlertingAuthContext(ctx), "rule", "default").Execute()
+
+	sloClient, err := client.GetSloClient()
+	require.NoError(t, err)
+	_, _, _ = sloClient.GetSloOp(client.SetSloAuthContext(ctx), "default", "slo").KbnXsrf("true").Execute()
+
+	connectorsClient, err := client.GetKibanaConnectorsClient(ctx)
+	require.NoError(t, err)
+	_, _ = connectorsClient.GetConnector(ctx, "default", "connector")
+
+	fleetClient, err := client.GetFleetClient()
+	require.NoError(t, err)
+	_, _ = fleetClient.API.GetEnrollmentApiKeys(ctx)
+}
+
+// callKibanaClients sends a single request through each of the Kibana backed clients.
+func callKibanaClients(t *testing.T, config KibanaConfig) {
+	baseConfig := BaseConfig{UserAgent: buildUserAgent("test")}
+	apiClient := &ApiClient{kibanaConfig: config}
+	ctx := context.Background()
+
+	kib, diags := buildKibanaClient(config)
+	require.False(t, diags.HasError())
+	_, _ = kib.KibanaSpaces.Get("space")
+
+	alertingClient, err := buildAlertingClient(baseConfig, config)
+	require.NoError(t, err)
+	_, _, _ = alertingClient.AlertingApi.GetRule(apiClient.SetAlertingAuthContext(ctx), "rule", "default").Execute()
+
+	sloClient, err := buildSloClient(baseConfig, config)
+	require.NoError(t, err)
+	_, _, _ = sloClient.SloAPI.GetSloOp(apiClient.SetSloAuthContext(ctx), "default", "slo").KbnXsrf("true").Execute()
+
+	connectorsClient, err := buildConnectorsClient(baseConfig, config)
+	require.NoError(t, err)
+	_, _ = connectorsClient.GetConnector(ctx, "default", "connector")
+
+	fleetClient, err := fleet.NewClient(fleet.Config{
+		URL:         config.Address,
+		Username:    config.Username,
+		Password:    config.Password,
+		APIKey:      config.APIKey,
+		BearerToken: config.BearerToken,
+		CAData:      config.CAData,
+		CertData:    config.CertData,
+		KeyData:     config.KeyData,
+		Retry:       config.Retry,
+	})
+	require.NoError(t, err)
+	_, _ = fleetClient.API.GetEnrollmentApiKeys(ctx)
+}
+
+// generateTestCertificate creates a certificate signed by the given parent, or a self-signed CA when parent is nil.
+func generateTestCertificate(t *testing.T, commonName string, parent *tls.Certificate) (tls.Certificate, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer = parent.Leaf
+		signerKey = parent.PrivateKey.(*rsa.PrivateKey)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func Test_serverFlavor(t *testing.T) {
+	for _, flavor := range []string{"default", ServerlessFlavor} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			_, _ = fmt.Fprintf(w, `{"cluster_name":"cluster","cluster_uuid":"uuid","version":{"number":"8.11.0","build_flavor":%q}}`, flavor)
+		}))
+		defer server.Close()
+
+		t.Setenv("ELASTICSEARCH_ENDPOINTS", "")
+		client, diags := newApiClient(frameworkConfig{
+			esKey: []interface{}{map[string]interface{}{"endpoints": []interface{}{server.URL}}},
+		}, "test")
+		require.False(t, diags.HasError())
+
+		serverFlavor, diags := client.ServerFlavor(context.Background())
+		require.False(t, diags.HasError())
+		require.Equal(t, flavor, serverFlavor)
+	}
+}
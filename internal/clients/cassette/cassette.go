@@ -0,0 +1,353 @@
+// Package cassette records the HTTP interactions of the acceptance tests into per-test cassette files, and replays
+// them so that the tests can run without an Elasticsearch, Kibana or Fleet server.
+//
+// The mode is set with the TF_ACC_CASSETTE_MODE environment variable:
+//   - record sends the requests to the servers and saves the interactions to testdata/cassettes/<TestName>.json.
+//   - replay serves the responses from the cassette, and fails the requests which weren't recorded.
+//
+// All clients send their requests through Transport. The cassette of a test is started by acctest.PreCheck, or
+// earlier by the tests generating random resource names: Start seeds the random names from the name of the test, so
+// that the recorded requests are sent again when replaying it.
+// Credentials are never recorded: request headers are dropped, only a few response headers are kept, and the
+// values of secret attributes, e.g. `password` or `api_key`, are redacted from the request and response bodies.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Mode is the cassette mode of the acceptance tests.
+type Mode string
+
+const (
+	// Off sends the requests to the servers without recording them.
+	Off Mode = ""
+	// Record sends the requests to the servers and records the interactions.
+	Record Mode = "record"
+	// Replay serves the recorded interactions.
+	Replay Mode = "replay"
+
+	// ModeEnvVar is the environment variable selecting the cassette mode.
+	ModeEnvVar = "TF_ACC_CASSETTE_MODE"
+
+	redacted = "REDACTED"
+)
+
+// Dir is the directory of the cassette files, relative to the package of the tests.
+var Dir = filepath.Join("testdata", "cassettes")
+
+// secretKeys are the attributes whose values are redacted from the recorded bodies.
+var secretKeys = map[string]bool{
+	"password":              true,
+	"password_hash":         true,
+	"api_key":               true,
+	"encoded":               true,
+	"secrets":               true,
+	"token":                 true,
+	"access_token":          true,
+	"refresh_token":         true,
+	"client_authentication": true,
+}
+
+// recordedHeaders are the response headers kept in the cassettes.
+var recordedHeaders = []string{"Content-Type", "X-Elastic-Product"}
+
+// CurrentMode returns the cassette mode set in the environment.
+func CurrentMode() (Mode, error) {
+	switch mode := Mode(os.Getenv(ModeEnvVar)); mode {
+	case Off, Record, Replay:
+		return mode, nil
+	default:
+		return Off, fmt.Errorf("invalid %s %q, expected %q or %q", ModeEnvVar, mode, Record, Replay)
+	}
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	// URL is the path and query of the request, the address of the server isn't recorded.
+	URL  string `json:"url"`
+	Body string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int                 `json:"status_code"`
+	Header     map[string][]string `json:"header,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+// Cassette holds the interactions of a test.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+
+	path string
+	mode Mode
+	mu   sync.Mutex
+	used []bool
+}
+
+// New returns the cassette stored in path. The cassette is loaded from the file in replay mode.
+func New(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode}
+	if mode != Replay {
+		return c, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+	}
+	c.used = make([]bool, len(c.Interactions))
+	return c, nil
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (c *Cassette) Save() error {
+	if c.mode != Record {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(b, '\n'), 0o644)
+}
+
+func (c *Cassette) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	request, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.mode == Replay {
+		return c.replay(req, request)
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := map[string][]string{}
+	for _, key := range recordedHeaders {
+		if values := resp.Header.Values(key); len(values) > 0 {
+			header[key] = values
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, Interaction{
+		Request: request,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       scrub(body),
+		},
+	})
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request. Identical requests, e.g. reading a resource
+// before and after an update, are served in the recorded order.
+func (c *Cassette) replay(req *http.Request, request Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.Interactions {
+		if c.used[i] || interaction.Request != request {
+			continue
+		}
+		c.used[i] = true
+
+		header := http.Header{}
+		for key, values := range interaction.Response.Header {
+			header[http.CanonicalHeaderKey(key)] = values
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no interaction recorded in %s for %s %s", c.path, request.Method, request.URL)
+}
+
+// newRequest returns the recorded form of the request. The body of the request is restored so that it can still be
+// sent.
+func newRequest(req *http.Request) (Request, error) {
+	request := Request{Method: req.Method, URL: req.URL.RequestURI()}
+	if req.Body == nil || req.Body == http.NoBody {
+		return request, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return request, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	request.Body = scrub(body)
+	return request, nil
+}
+
+// scrub redacts the secret attributes of a JSON body. JSON bodies are also normalised, so that requests can be
+// matched regardless of the formatting. Other bodies are returned as is.
+func scrub(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+	b, err := json.Marshal(redact(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, attr := range v {
+			if secretKeys[key] && attr != nil {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redact(attr)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+	return value
+}
+
+var (
+	activeMu sync.Mutex
+	active   *Cassette
+)
+
+// Start starts the cassette of the test, and saves it when the test completes. The cassette is named after the test,
+// and nothing is done when the acceptance tests aren't enabled or the cassette of the test is already started.
+//
+// The random names of the resources are part of the recorded requests, so the random numbers are seeded from the
+// name of the test. Start must be called before the names are generated. A test without a cassette fails when
+// replaying.
+func Start(t testing.TB) {
+	mode, err := CurrentMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode == Off || os.Getenv("TF_ACC") == "" {
+		return
+	}
+
+	path := filepath.Join(Dir, strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())+".json")
+	if c := current(); c != nil && c.path == path {
+		return
+	}
+
+	c, err := New(path, mode)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("no cassette recorded in %s, record it with `make testacc-record`", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name()))
+	rand.Seed(int64(h.Sum64())) //nolint:staticcheck
+
+	activeMu.Lock()
+	active = c
+	activeMu.Unlock()
+
+	t.Cleanup(func() {
+		activeMu.Lock()
+		if active == c {
+			active = nil
+		}
+		activeMu.Unlock()
+
+		if err := c.Save(); err != nil {
+			t.Errorf("unable to save cassette: %s", err)
+		}
+	})
+}
+
+func current() *Cassette {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	return active
+}
+
+type transport struct {
+	mode Mode
+	next http.RoundTripper
+}
+
+// Transport wraps the transport sending the requests to the server, so that the interactions are recorded into or
+// replayed from the cassette of the running test. The transport is returned as is when no cassette mode is set.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	mode, _ := CurrentMode()
+	if mode == Off {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{mode: mode, next: next}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := current()
+	if c == nil {
+		if t.mode == Replay {
+			return nil, fmt.Errorf("no cassette started to replay %s %s, call acctest.PreCheck in the test", req.Method, req.URL.RequestURI())
+		}
+		return t.next.RoundTrip(req)
+	}
+	return c.roundTrip(t.next, req)
+}
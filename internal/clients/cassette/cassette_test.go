@@ -0,0 +1,144 @@
+package cassette
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "sid=secret")
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"calls": `+strings.Repeat("1", calls)+`}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": "key", "api_key": "secret", "nested": [{"password": "secret"}]}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "TestCassette.json")
+	recorder, err := New(path, Record)
+	require.NoError(t, err)
+
+	send := func(c *Cassette, method, body string) (*http.Response, string, error) {
+		req, err := http.NewRequest(method, server.URL+"/_security/api_key?refresh=true", strings.NewReader(body))
+		require.NoError(t, err)
+		req.SetBasicAuth("elastic", "changeme")
+		resp, err := c.roundTrip(http.DefaultTransport, req)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(b), nil
+	}
+
+	_, body, err := send(recorder, http.MethodPost, `{"name": "key",  "password": "changeme"}`)
+	require.NoError(t, err)
+	require.Contains(t, body, `"api_key": "secret"`, "recording doesn't alter the responses")
+	_, body, err = send(recorder, http.MethodGet, "")
+	require.NoError(t, err)
+	require.Equal(t, `{"calls": 11}`, body)
+	_, body, err = send(recorder, http.MethodGet, "")
+	require.NoError(t, err)
+	require.Equal(t, `{"calls": 111}`, body)
+	require.NoError(t, recorder.Save())
+
+	recorded, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"changeme", "secret", "Authorization", "Set-Cookie", "127.0.0.1"} {
+		require.NotContains(t, string(recorded), secret)
+	}
+
+	player, err := New(path, Replay)
+	require.NoError(t, err)
+
+	resp, body, err := send(player, http.MethodPost, `{"password":"another","name":"key"}`)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.JSONEq(t, `{"id": "key", "api_key": "REDACTED", "nested": [{"password": "REDACTED"}]}`, body)
+
+	_, body, err = send(player, http.MethodGet, "")
+	require.NoError(t, err)
+	require.Equal(t, `{"calls":11}`, body)
+	_, body, err = send(player, http.MethodGet, "")
+	require.NoError(t, err)
+	require.Equal(t, `{"calls":111}`, body)
+
+	_, _, err = send(player, http.MethodGet, "")
+	require.ErrorContains(t, err, "no interaction recorded")
+	require.Equal(t, 3, calls, "replayed requests aren't sent to the server")
+}
+
+func TestTransport(t *testing.T) {
+	t.Setenv(ModeEnvVar, "")
+	require.Equal(t, http.DefaultTransport, Transport(http.DefaultTransport))
+
+	t.Setenv(ModeEnvVar, string(Replay))
+	req, err := http.NewRequest(http.MethodGet, "http://elasticsearch:9200/", nil)
+	require.NoError(t, err)
+	_, err = Transport(http.DefaultTransport).RoundTrip(req)
+	require.ErrorContains(t, err, "no cassette started")
+
+	t.Setenv(ModeEnvVar, "invalid")
+	_, err = CurrentMode()
+	require.Error(t, err)
+}
+
+func TestStart(t *testing.T) {
+	t.Setenv("TF_ACC", "1")
+	t.Setenv(ModeEnvVar, string(Replay))
+	dir := Dir
+	Dir = t.TempDir()
+	t.Cleanup(func() { Dir = dir })
+
+	missing := &fatalRecorder{TB: t}
+	func() {
+		defer func() { _ = recover() }()
+		Start(missing)
+	}()
+	require.Contains(t, missing.fatal, "no cassette recorded", "a test without a cassette fails")
+
+	require.NoError(t, os.WriteFile(filepath.Join(Dir, "TestStart_recorded.json"), []byte(`{"interactions": []}`), 0o644))
+	t.Run("recorded", func(t *testing.T) {
+		Start(t)
+		c := current()
+		require.NotNil(t, c)
+		Start(t)
+		require.Same(t, c, current(), "the cassette is only started once")
+
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(t.Name()))
+		want := rand.New(rand.NewSource(int64(h.Sum64()))).Int63()                               //nolint:gosec
+		require.Equal(t, want, rand.Int63(), "the random numbers are seeded from the test name") //nolint:gosec
+	})
+	require.Nil(t, current(), "the cassette is stopped with the test")
+}
+
+// fatalRecorder records the message of Fatalf rather than failing the test.
+type fatalRecorder struct {
+	testing.TB
+	fatal string
+}
+
+func (r *fatalRecorder) Name() string { return "TestStart_missing" }
+
+func (r *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	r.fatal = fmt.Sprintf(format, args...)
+	panic(r.fatal)
+}
@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// cloudEndpoints holds the endpoints of an Elastic Cloud deployment.
+type cloudEndpoints struct {
+	Elasticsearch string
+	Kibana        string
+}
+
+// parseCloudID decodes an Elastic Cloud ID, formatted as <name>:<base64 encoded host$es_uuid$kibana_uuid>.
+// The host may include a port, which defaults to 443.
+func parseCloudID(cloudID string) (cloudEndpoints, error) {
+	_, encoded, found := strings.Cut(cloudID, ":")
+	if !found || encoded == "" {
+		return cloudEndpoints{}, fmt.Errorf("expected <name>:<encoded value>")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return cloudEndpoints{}, fmt.Errorf("unable to decode the cloud ID: %w", err)
+	}
+
+	parts := strings.Split(string(decoded), "$")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return cloudEndpoints{}, fmt.Errorf("expected the decoded cloud ID to contain the host and the Elasticsearch ID")
+	}
+
+	host, port, found := strings.Cut(parts[0], ":")
+	if !found {
+		port = "443"
+	}
+
+	endpoints := cloudEndpoints{
+		Elasticsearch: fmt.Sprintf("https://%s.%s:%s", parts[1], host, port),
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		endpoints.Kibana = fmt.Sprintf("https://%s.%s:%s", parts[2], host, port)
+	}
+	return endpoints, nil
+}
+
+// parseCloudAuth splits Elastic Cloud credentials, formatted as <username>:<password>.
+func parseCloudAuth(cloudAuth string) (string, string, error) {
+	username, password, found := strings.Cut(cloudAuth, ":")
+	if !found || username == "" {
+		return "", "", fmt.Errorf("expected <username>:<password>")
+	}
+	return username, password, nil
+}
@@ -0,0 +1,108 @@
+package clients
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseCloudID(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name          string
+		cloudID       string
+		expected      cloudEndpoints
+		expectedError string
+	}{
+		{
+			name:    "derives the Elasticsearch and Kibana endpoints",
+			cloudID: "my-deployment:" + encode("us-east-1.aws.found.io$es-id$kb-id"),
+			expected: cloudEndpoints{
+				Elasticsearch: "https://es-id.us-east-1.aws.found.io:443",
+				Kibana:        "https://kb-id.us-east-1.aws.found.io:443",
+			},
+		},
+		{
+			name:    "keeps the port of the host",
+			cloudID: "my-deployment:" + encode("localhost:9243$es-id$kb-id"),
+			expected: cloudEndpoints{
+				Elasticsearch: "https://es-id.localhost:9243",
+				Kibana:        "https://kb-id.localhost:9243",
+			},
+		},
+		{
+			name:     "Kibana is optional",
+			cloudID:  "my-deployment:" + encode("us-east-1.aws.found.io$es-id"),
+			expected: cloudEndpoints{Elasticsearch: "https://es-id.us-east-1.aws.found.io:443"},
+		},
+		{
+			name:          "requires a name",
+			cloudID:       encode("us-east-1.aws.found.io$es-id$kb-id"),
+			expectedError: "expected <name>:<encoded value>",
+		},
+		{
+			name:          "requires base64",
+			cloudID:       "my-deployment:not base64",
+			expectedError: "unable to decode the cloud ID",
+		},
+		{
+			name:          "requires the Elasticsearch ID",
+			cloudID:       "my-deployment:" + encode("us-east-1.aws.found.io"),
+			expectedError: "expected the decoded cloud ID to contain the host and the Elasticsearch ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints, err := parseCloudID(tt.cloudID)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, endpoints)
+		})
+	}
+}
+
+func Test_newApiClientWithCloudID(t *testing.T) {
+	cloudID := "my-deployment:" + base64.StdEncoding.EncodeToString([]byte("us-east-1.aws.found.io$es-id$kb-id"))
+	for _, env := range []string{"ELASTICSEARCH_ENDPOINTS", "KIBANA_ENDPOINT", "FLEET_ENDPOINT"} {
+		t.Setenv(env, "")
+	}
+
+	client, diags := newApiClient(frameworkConfig{
+		esKey: []interface{}{map[string]interface{}{
+			"cloud_id":   cloudID,
+			"cloud_auth": "elastic:changeme",
+		}},
+	}, "test")
+	require.False(t, diags.HasError(), "%v", diags)
+
+	require.Equal(t, "https://es-id.us-east-1.aws.found.io:443", client.elasticsearch.Transport.(interface{ URLs() []*url.URL }).URLs()[0].String())
+	require.Equal(t, "https://kb-id.us-east-1.aws.found.io:443", client.kibanaConfig.Address)
+	require.Equal(t, "elastic", client.kibanaConfig.Username)
+	require.Equal(t, "changeme", client.kibanaConfig.Password)
+	require.Equal(t, "https://kb-id.us-east-1.aws.found.io:443", client.fleet.URL)
+
+	_, diags = newApiClient(frameworkConfig{
+		esKey: []interface{}{map[string]interface{}{
+			"cloud_id":  cloudID,
+			"endpoints": []interface{}{"http://localhost:9200"},
+		}},
+	}, "test")
+	require.True(t, diags.HasError())
+	require.Equal(t, "Only one of cloud_id and endpoints can be set in the elasticsearch block", diags[0].Detail)
+
+	_, diags = newApiClient(frameworkConfig{
+		"kibana": []interface{}{map[string]interface{}{
+			"cloud_id":  cloudID,
+			"endpoints": []interface{}{"http://localhost:5601"},
+		}},
+	}, "test")
+	require.True(t, diags.HasError())
+	require.Equal(t, "Only one of cloud_id and endpoints can be set in the kibana block", diags[0].Detail)
+}
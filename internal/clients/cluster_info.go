@@ -0,0 +1,59 @@
+package clients
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+)
+
+// clusterInfoCache holds the info of each Elasticsearch cluster the provider talks to, so that it's only
+// requested once. It's shared by the provider client and every client built for a resource connection.
+type clusterInfoCache struct {
+	mu    sync.Mutex
+	infos map[string]*models.ClusterInfo
+}
+
+func newClusterInfoCache() *clusterInfoCache {
+	return &clusterInfoCache{infos: map[string]*models.ClusterInfo{}}
+}
+
+func (c *clusterInfoCache) get(connectionID string) (*models.ClusterInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	info, ok := c.infos[connectionID]
+	return info, ok
+}
+
+func (c *clusterInfoCache) set(connectionID string, info *models.ClusterInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.infos[connectionID] = info
+}
+
+// esConnectionID identifies an Elasticsearch connection by its endpoints and credentials.
+// The credentials are hashed, so that they aren't held in memory any longer than the client config.
+func esConnectionID(config elasticsearch.Config) string {
+	h := sha256.New()
+	write := func(values ...string) {
+		for _, v := range values {
+			h.Write([]byte(v))
+			h.Write([]byte{0})
+		}
+	}
+
+	write(strings.Join(config.Addresses, ","), config.CloudID, config.Username, config.Password, config.APIKey, config.ServiceToken, config.Header.Get("ES-Client-Authentication"))
+	if transport, ok := config.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		for _, cert := range transport.TLSClientConfig.Certificates {
+			for _, der := range cert.Certificate {
+				h.Write(der)
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
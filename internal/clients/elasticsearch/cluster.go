@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -23,7 +24,11 @@ func PutSnapshotRepository(ctx context.Context, apiClient *clients.ApiClient, re
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Snapshot.CreateRepository(repository.Name, bytes.NewReader(snapRepoBytes), esClient.Snapshot.CreateRepository.WithContext(ctx))
+	opts := []func(*esapi.SnapshotCreateRepositoryRequest){esClient.Snapshot.CreateRepository.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Snapshot.CreateRepository.WithMasterTimeout(timeout), esClient.Snapshot.CreateRepository.WithTimeout(timeout))
+	}
+	res, err := esClient.Snapshot.CreateRepository(repository.Name, bytes.NewReader(snapRepoBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -79,7 +84,11 @@ func DeleteSnapshotRepository(ctx context.Context, apiClient *clients.ApiClient,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Snapshot.DeleteRepository([]string{name}, esClient.Snapshot.DeleteRepository.WithContext(ctx))
+	opts := []func(*esapi.SnapshotDeleteRepositoryRequest){esClient.Snapshot.DeleteRepository.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Snapshot.DeleteRepository.WithMasterTimeout(timeout), esClient.Snapshot.DeleteRepository.WithTimeout(timeout))
+	}
+	res, err := esClient.Snapshot.DeleteRepository([]string{name}, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -178,7 +187,11 @@ func PutSettings(ctx context.Context, apiClient *clients.ApiClient, settings map
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Cluster.PutSettings(bytes.NewReader(settingsBytes), esClient.Cluster.PutSettings.WithContext(ctx))
+	opts := []func(*esapi.ClusterPutSettingsRequest){esClient.Cluster.PutSettings.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Cluster.PutSettings.WithMasterTimeout(timeout), esClient.Cluster.PutSettings.WithTimeout(timeout))
+	}
+	res, err := esClient.Cluster.PutSettings(bytes.NewReader(settingsBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -252,7 +265,11 @@ func PutScript(ctx context.Context, apiClient *clients.ApiClient, script *models
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.PutScript(script.ID, bytes.NewReader(scriptBytes), esClient.PutScript.WithContext(ctx), esClient.PutScript.WithScriptContext(script.Context))
+	opts := []func(*esapi.PutScriptRequest){esClient.PutScript.WithContext(ctx), esClient.PutScript.WithScriptContext(script.Context)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.PutScript.WithMasterTimeout(timeout), esClient.PutScript.WithTimeout(timeout))
+	}
+	res, err := esClient.PutScript(script.ID, bytes.NewReader(scriptBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -268,7 +285,11 @@ func DeleteScript(ctx context.Context, apiClient *clients.ApiClient, id string)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.DeleteScript(id, esClient.DeleteScript.WithContext(ctx))
+	opts := []func(*esapi.DeleteScriptRequest){esClient.DeleteScript.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.DeleteScript.WithMasterTimeout(timeout), esClient.DeleteScript.WithTimeout(timeout))
+	}
+	res, err := esClient.DeleteScript(id, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
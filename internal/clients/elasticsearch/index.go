@@ -6,12 +6,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
@@ -73,6 +76,28 @@ func GetIlm(ctx context.Context, apiClient *clients.ApiClient, policyName string
 	return nil, diags
 }
 
+// ListIlm returns all the ILM policies of the cluster, keyed by name.
+func ListIlm(ctx context.Context, apiClient *clients.ApiClient) (map[string]models.PolicyDefinition, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.ILM.GetLifecycle(esClient.ILM.GetLifecycle.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to list the ILM policies."); diags.HasError() {
+		return nil, diags
+	}
+
+	ilm := make(map[string]models.PolicyDefinition)
+	if err := json.NewDecoder(res.Body).Decode(&ilm); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return ilm, nil
+}
+
 func DeleteIlm(ctx context.Context, apiClient *clients.ApiClient, policyName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -91,6 +116,14 @@ func DeleteIlm(ctx context.Context, apiClient *clients.ApiClient, policyName str
 	return diags
 }
 
+// templateErrorPaths reports mapping and setting errors on the attributes of the template block defining them.
+var templateErrorPaths = []utils.ErrorPathResolver{
+	utils.MappingsErrorPath(cty.GetAttrPath("template").IndexInt(0).GetAttr("mappings")),
+	utils.SettingErrorPath(func(string) cty.Path {
+		return cty.GetAttrPath("template").IndexInt(0).GetAttr("settings")
+	}),
+}
+
 func PutComponentTemplate(ctx context.Context, apiClient *clients.ApiClient, template *models.ComponentTemplate) diag.Diagnostics {
 	var diags diag.Diagnostics
 	templateBytes, err := json.Marshal(template)
@@ -102,12 +135,16 @@ func PutComponentTemplate(ctx context.Context, apiClient *clients.ApiClient, tem
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Cluster.PutComponentTemplate(template.Name, bytes.NewReader(templateBytes), esClient.Cluster.PutComponentTemplate.WithContext(ctx))
+	opts := []func(*esapi.ClusterPutComponentTemplateRequest){esClient.Cluster.PutComponentTemplate.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Cluster.PutComponentTemplate.WithMasterTimeout(timeout), esClient.Cluster.PutComponentTemplate.WithTimeout(timeout))
+	}
+	res, err := esClient.Cluster.PutComponentTemplate(template.Name, bytes.NewReader(templateBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to create component template"); diags.HasError() {
+	if diags := utils.CheckError(res, "Unable to create component template", templateErrorPaths...); diags.HasError() {
 		return diags
 	}
 
@@ -157,7 +194,11 @@ func DeleteComponentTemplate(ctx context.Context, apiClient *clients.ApiClient,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Cluster.DeleteComponentTemplate(templateName, esClient.Cluster.DeleteComponentTemplate.WithContext(ctx))
+	opts := []func(*esapi.ClusterDeleteComponentTemplateRequest){esClient.Cluster.DeleteComponentTemplate.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Cluster.DeleteComponentTemplate.WithMasterTimeout(timeout), esClient.Cluster.DeleteComponentTemplate.WithTimeout(timeout))
+	}
+	res, err := esClient.Cluster.DeleteComponentTemplate(templateName, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -179,12 +220,16 @@ func PutIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Indices.PutIndexTemplate(template.Name, bytes.NewReader(templateBytes), esClient.Indices.PutIndexTemplate.WithContext(ctx))
+	opts := []func(*esapi.IndicesPutIndexTemplateRequest){esClient.Indices.PutIndexTemplate.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Indices.PutIndexTemplate.WithMasterTimeout(timeout))
+	}
+	res, err := esClient.Indices.PutIndexTemplate(template.Name, bytes.NewReader(templateBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to create index template"); diags.HasError() {
+	if diags := utils.CheckError(res, "Unable to create index template", templateErrorPaths...); diags.HasError() {
 		return diags
 	}
 
@@ -228,13 +273,39 @@ func GetIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templat
 	return &tpl, diags
 }
 
+// ListIndexTemplates returns all the index templates of the cluster.
+func ListIndexTemplates(ctx context.Context, apiClient *clients.ApiClient) ([]models.IndexTemplateResponse, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Indices.GetIndexTemplate(esClient.Indices.GetIndexTemplate.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to list the index templates."); diags.HasError() {
+		return nil, diags
+	}
+
+	var indexTemplates models.IndexTemplatesResponse
+	if err := json.NewDecoder(res.Body).Decode(&indexTemplates); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return indexTemplates.IndexTemplates, nil
+}
+
 func DeleteIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, templateName string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	esClient, err := apiClient.GetESClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Indices.DeleteIndexTemplate(templateName, esClient.Indices.DeleteIndexTemplate.WithContext(ctx))
+	opts := []func(*esapi.IndicesDeleteIndexTemplateRequest){esClient.Indices.DeleteIndexTemplate.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Indices.DeleteIndexTemplate.WithMasterTimeout(timeout), esClient.Indices.DeleteIndexTemplate.WithTimeout(timeout))
+	}
+	res, err := esClient.Indices.DeleteIndexTemplate(templateName, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -245,7 +316,7 @@ func DeleteIndexTemplate(ctx context.Context, apiClient *clients.ApiClient, temp
 	return diags
 }
 
-func PutIndex(ctx context.Context, apiClient *clients.ApiClient, index *models.Index, params *models.PutIndexParams) diag.Diagnostics {
+func PutIndex(ctx context.Context, apiClient *clients.ApiClient, index *models.Index, params *models.PutIndexParams, errorPaths ...utils.ErrorPathResolver) diag.Diagnostics {
 	var diags diag.Diagnostics
 	indexBytes, err := json.Marshal(index)
 	if err != nil {
@@ -275,7 +346,7 @@ func PutIndex(ctx context.Context, apiClient *clients.ApiClient, index *models.I
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, fmt.Sprintf("Unable to create index: %s", index.Name)); diags.HasError() {
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to create index: %s", index.Name), errorPaths...); diags.HasError() {
 		return diags
 	}
 	return diags
@@ -300,6 +371,146 @@ func DeleteIndex(ctx context.Context, apiClient *clients.ApiClient, name string)
 	return diags
 }
 
+// GetIndices returns the indices matching the search, which may contain wildcards and aliases, keyed by their name.
+func GetIndices(ctx context.Context, apiClient *clients.ApiClient, search, expandWildcards string) (map[string]models.Index, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Indices.Get(
+		[]string{search},
+		esClient.Indices.Get.WithFlatSettings(true),
+		esClient.Indices.Get.WithExpandWildcards(expandWildcards),
+		esClient.Indices.Get.WithIgnoreUnavailable(true),
+		esClient.Indices.Get.WithAllowNoIndices(true),
+		esClient.Indices.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the indices: %s", search)); diags.HasError() {
+		return nil, diags
+	}
+
+	indices := make(map[string]models.Index)
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for name, index := range indices {
+		index.Name = name
+		indices[name] = index
+	}
+	return indices, nil
+}
+
+// CatIndices returns the health, status and statistics of the indices matching the search, with sizes in bytes.
+func CatIndices(ctx context.Context, apiClient *clients.ApiClient, search, expandWildcards string) ([]models.CatIndex, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	// the client doesn't expose the indices options of the cat indices API, which are needed to skip the missing indices
+	query := url.Values{
+		"expand_wildcards":   {expandWildcards},
+		"ignore_unavailable": {"true"},
+		"allow_no_indices":   {"true"},
+		"bytes":              {"b"},
+		"h":                  {"index,uuid,health,status,docs.count,store.size"},
+		"format":             {"json"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/_cat/indices/"+search+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	httpRes, err := esClient.Perform(req)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res := &esapi.Response{StatusCode: httpRes.StatusCode, Body: httpRes.Body, Header: httpRes.Header}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to list the indices: %s", search)); diags.HasError() {
+		return nil, diags
+	}
+
+	var indices []models.CatIndex
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return indices, nil
+}
+
+// CloseIndex closes the index, blocking reads and writes, so that its static settings can be updated.
+func CloseIndex(ctx context.Context, apiClient *clients.ApiClient, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Indices.Close([]string{name}, esClient.Indices.Close.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to close the index: %s", name)); diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+// OpenIndex reopens a closed index.
+func OpenIndex(ctx context.Context, apiClient *clients.ApiClient, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Indices.Open([]string{name}, esClient.Indices.Open.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to open the index: %s", name)); diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+// WaitForIndexHealth waits for the primary shards of the index to be allocated, the index health being yellow or
+// green.
+func WaitForIndexHealth(ctx context.Context, apiClient *clients.ApiClient, name string) diag.Diagnostics {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for {
+		res, err := esClient.Cluster.Health(
+			esClient.Cluster.Health.WithContext(ctx),
+			esClient.Cluster.Health.WithIndex(name),
+			esClient.Cluster.Health.WithWaitForStatus("yellow"),
+			esClient.Cluster.Health.WithTimeout(30*time.Second),
+		)
+		if err != nil {
+			return diag.Errorf("Index %s didn't become available: %s", name, err)
+		}
+		// the index is still red at the timeout of the request
+		if res.StatusCode == http.StatusRequestTimeout {
+			res.Body.Close()
+			continue
+		}
+		defer res.Body.Close()
+		if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the health of the index: %s", name)); diags.HasError() {
+			return diags
+		}
+		return nil
+	}
+}
+
 func GetIndex(ctx context.Context, apiClient *clients.ApiClient, name string) (*models.Index, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -326,7 +537,19 @@ func GetIndex(ctx context.Context, apiClient *clients.ApiClient, name string) (*
 	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
 		return nil, diag.FromErr(err)
 	}
-	index := indices[name]
+	index, ok := indices[name]
+	if ok {
+		index.Name = name
+		return &index, diags
+	}
+	// the name is an alias, which must point to a single index
+	if len(indices) > 1 {
+		return nil, diag.Errorf("Alias '%s' points to several indices, expected a single index", name)
+	}
+	for concreteName, index := range indices {
+		index.Name = concreteName
+		return &index, diags
+	}
 	return &index, diags
 }
 
@@ -369,7 +592,168 @@ func UpdateIndexAlias(ctx context.Context, apiClient *clients.ApiClient, index s
 	return diags
 }
 
-func UpdateIndexSettings(ctx context.Context, apiClient *clients.ApiClient, index string, settings map[string]interface{}) diag.Diagnostics {
+// UpdateAliases applies the alias actions atomically.
+func UpdateAliases(ctx context.Context, apiClient *clients.ApiClient, actions []models.AliasAction) diag.Diagnostics {
+	var diags diag.Diagnostics
+	actionsBytes, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Indices.UpdateAliases(bytes.NewReader(actionsBytes), esClient.Indices.UpdateAliases.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update aliases"); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// GetAlias returns the definition of the alias on each of the indices and data streams it points to, keyed by their
+// name, or nil if the alias doesn't exist.
+func GetAlias(ctx context.Context, apiClient *clients.ApiClient, name string) (map[string]models.IndexAlias, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Indices.GetAlias(esClient.Indices.GetAlias.WithName(name), esClient.Indices.GetAlias.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the alias: %s", name)); diags.HasError() {
+		return nil, diags
+	}
+
+	indices := make(map[string]struct {
+		Aliases map[string]models.IndexAlias `json:"aliases"`
+	})
+	if err := json.NewDecoder(res.Body).Decode(&indices); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	members := make(map[string]models.IndexAlias, len(indices))
+	for index, definition := range indices {
+		if alias, ok := definition.Aliases[name]; ok {
+			alias.Name = name
+			members[index] = alias
+		}
+	}
+	return members, nil
+}
+
+// Reindex starts copying the documents of the source index into the destination index, and returns the ID of the
+// task running the reindex.
+func Reindex(ctx context.Context, apiClient *clients.ApiClient, source, dest string) (string, diag.Diagnostics) {
+	body, err := json.Marshal(map[string]interface{}{
+		"source": map[string]interface{}{"index": source},
+		"dest":   map[string]interface{}{"index": dest},
+	})
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	res, err := esClient.Reindex(
+		bytes.NewReader(body),
+		esClient.Reindex.WithContext(ctx),
+		esClient.Reindex.WithWaitForCompletion(false),
+		esClient.Reindex.WithRefresh(true),
+	)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to reindex '%s' into '%s'", source, dest)); diags.HasError() {
+		return "", diags
+	}
+
+	var task struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&task); err != nil {
+		return "", diag.FromErr(err)
+	}
+	return task.Task, nil
+}
+
+// WaitForTask waits for the task to complete, and reports the error or the failures of the task.
+func WaitForTask(ctx context.Context, apiClient *clients.ApiClient, taskID string) diag.Diagnostics {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for {
+		res, err := esClient.Tasks.Get(
+			taskID,
+			esClient.Tasks.Get.WithContext(ctx),
+			esClient.Tasks.Get.WithWaitForCompletion(true),
+			esClient.Tasks.Get.WithTimeout(30*time.Second),
+		)
+		if err != nil {
+			return diag.Errorf("Task %s didn't complete: %s", taskID, err)
+		}
+		// the task didn't complete before the timeout of the request
+		if res.StatusCode == http.StatusRequestTimeout {
+			res.Body.Close()
+			continue
+		}
+
+		task, diags := decodeTask(res, taskID)
+		if diags.HasError() {
+			return diags
+		}
+		if task.Error != nil {
+			return diag.Errorf("Task %s failed: %v", taskID, task.Error["reason"])
+		}
+		if failures, ok := task.Response["failures"].([]interface{}); ok && len(failures) > 0 {
+			return diag.Errorf("Task %s completed with %d failures, the first one is: %v", taskID, len(failures), failures[0])
+		}
+		return nil
+	}
+}
+
+func decodeTask(res *esapi.Response, taskID string) (*models.Task, diag.Diagnostics) {
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to get the task: %s", taskID)); diags.HasError() {
+		return nil, diags
+	}
+	var task models.Task
+	if err := json.NewDecoder(res.Body).Decode(&task); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &task, nil
+}
+
+// CancelTask cancels the task.
+func CancelTask(ctx context.Context, apiClient *clients.ApiClient, taskID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := esClient.Tasks.Cancel(esClient.Tasks.Cancel.WithTaskID(taskID), esClient.Tasks.Cancel.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, fmt.Sprintf("Unable to cancel the task: %s", taskID)); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+func UpdateIndexSettings(ctx context.Context, apiClient *clients.ApiClient, index string, settings map[string]interface{}, errorPaths ...utils.ErrorPathResolver) diag.Diagnostics {
 	var diags diag.Diagnostics
 	settingsBytes, err := json.Marshal(settings)
 	if err != nil {
@@ -385,13 +769,13 @@ func UpdateIndexSettings(ctx context.Context, apiClient *clients.ApiClient, inde
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to update index settings"); diags.HasError() {
+	if diags := utils.CheckError(res, "Unable to update index settings", errorPaths...); diags.HasError() {
 		return diags
 	}
 	return diags
 }
 
-func UpdateIndexMappings(ctx context.Context, apiClient *clients.ApiClient, index, mappings string) diag.Diagnostics {
+func UpdateIndexMappings(ctx context.Context, apiClient *clients.ApiClient, index, mappings string, errorPaths ...utils.ErrorPathResolver) diag.Diagnostics {
 	var diags diag.Diagnostics
 	esClient, err := apiClient.GetESClient()
 	if err != nil {
@@ -403,7 +787,7 @@ func UpdateIndexMappings(ctx context.Context, apiClient *clients.ApiClient, inde
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
-	if diags := utils.CheckError(res, "Unable to update index mappings"); diags.HasError() {
+	if diags := utils.CheckError(res, "Unable to update index mappings", errorPaths...); diags.HasError() {
 		return diags
 	}
 	return diags
@@ -486,7 +870,11 @@ func PutIngestPipeline(ctx context.Context, apiClient *clients.ApiClient, pipeli
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Ingest.PutPipeline(pipeline.Name, bytes.NewReader(pipelineBytes), esClient.Ingest.PutPipeline.WithContext(ctx))
+	opts := []func(*esapi.IngestPutPipelineRequest){esClient.Ingest.PutPipeline.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Ingest.PutPipeline.WithMasterTimeout(timeout), esClient.Ingest.PutPipeline.WithTimeout(timeout))
+	}
+	res, err := esClient.Ingest.PutPipeline(pipeline.Name, bytes.NewReader(pipelineBytes), opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -534,7 +922,11 @@ func DeleteIngestPipeline(ctx context.Context, apiClient *clients.ApiClient, nam
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Ingest.DeletePipeline(*name, esClient.Ingest.DeletePipeline.WithContext(ctx))
+	opts := []func(*esapi.IngestDeletePipelineRequest){esClient.Ingest.DeletePipeline.WithContext(ctx)}
+	if timeout, ok := utils.TimeoutFromContext(ctx); ok {
+		opts = append(opts, esClient.Ingest.DeletePipeline.WithMasterTimeout(timeout), esClient.Ingest.DeletePipeline.WithTimeout(timeout))
+	}
+	res, err := esClient.Ingest.DeletePipeline(*name, opts...)
 	if err != nil {
 		return diags
 	}
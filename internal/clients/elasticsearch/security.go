@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
@@ -205,6 +208,28 @@ func GetRole(ctx context.Context, apiClient *clients.ApiClient, rolename string)
 	return nil, diags
 }
 
+// ListRoles returns all the roles of the cluster, keyed by name.
+func ListRoles(ctx context.Context, apiClient *clients.ApiClient) (map[string]models.Role, diag.Diagnostics) {
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := esClient.Security.GetRole(esClient.Security.GetRole.WithContext(ctx))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to list the roles."); diags.HasError() {
+		return nil, diags
+	}
+
+	roles := make(map[string]models.Role)
+	if err := json.NewDecoder(res.Body).Decode(&roles); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return roles, nil
+}
+
 func DeleteRole(ctx context.Context, apiClient *clients.ApiClient, rolename string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	esClient, err := apiClient.GetESClient()
@@ -292,7 +317,7 @@ func DeleteRoleMapping(ctx context.Context, apiClient *clients.ApiClient, roleMa
 	return nil
 }
 
-func PutApiKey(apiClient *clients.ApiClient, apikey *models.ApiKey) (*models.ApiKeyResponse, diag.Diagnostics) {
+func PutApiKey(ctx context.Context, apiClient *clients.ApiClient, apikey *models.ApiKey) (*models.ApiKeyResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	apikeyBytes, err := json.Marshal(apikey)
 	if err != nil {
@@ -303,7 +328,7 @@ func PutApiKey(apiClient *clients.ApiClient, apikey *models.ApiKey) (*models.Api
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	res, err := esClient.Security.CreateAPIKey(bytes.NewReader(apikeyBytes))
+	res, err := esClient.Security.CreateAPIKey(bytes.NewReader(apikeyBytes), esClient.Security.CreateAPIKey.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -321,14 +346,111 @@ func PutApiKey(apiClient *clients.ApiClient, apikey *models.ApiKey) (*models.Api
 	return &apiKey, diags
 }
 
-func GetApiKey(apiClient *clients.ApiClient, id string) (*models.ApiKeyResponse, diag.Diagnostics) {
+// UpdateApiKey updates the role descriptors and metadata of an existing API key, keeping its credentials. The API
+// is available from Elasticsearch 8.4.
+func UpdateApiKey(ctx context.Context, apiClient *clients.ApiClient, id string, apikey *models.ApiKey) diag.Diagnostics {
+	// Removing the role descriptors is sent as an empty object, so that the key gets the privileges of its owner.
+	roleDescriptors := apikey.RolesDescriptors
+	if roleDescriptors == nil {
+		roleDescriptors = map[string]models.Role{}
+	}
+	body := map[string]interface{}{"role_descriptors": roleDescriptors}
+	if apikey.Metadata != nil {
+		body["metadata"] = apikey.Metadata
+	}
+	apikeyBytes, err := json.Marshal(body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := perform(ctx, esClient, http.MethodPut, "/_security/api_key/"+url.PathEscape(id), apikeyBytes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update apikey"); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// PutCrossClusterApiKey creates a cross-cluster API key, which is available from Elasticsearch 8.10.
+func PutCrossClusterApiKey(ctx context.Context, apiClient *clients.ApiClient, apikey *models.CrossClusterApiKey) (*models.ApiKeyResponse, diag.Diagnostics) {
+	apikeyBytes, err := json.Marshal(apikey)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	res, err := perform(ctx, esClient, http.MethodPost, "/_security/cross_cluster/api_key", apikeyBytes)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to create cross-cluster apikey"); diags.HasError() {
+		return nil, diags
+	}
+
+	var apiKey models.ApiKeyResponse
+	if err := json.NewDecoder(res.Body).Decode(&apiKey); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return &apiKey, nil
+}
+
+// UpdateCrossClusterApiKey updates the access and metadata of an existing cross-cluster API key, keeping its
+// credentials.
+func UpdateCrossClusterApiKey(ctx context.Context, apiClient *clients.ApiClient, id string, apikey *models.CrossClusterApiKey) diag.Diagnostics {
+	// The name and expiration can't be updated.
+	apikeyBytes, err := json.Marshal(models.CrossClusterApiKey{Access: apikey.Access, Metadata: apikey.Metadata})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	esClient, err := apiClient.GetESClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, err := perform(ctx, esClient, http.MethodPut, "/_security/cross_cluster/api_key/"+url.PathEscape(id), apikeyBytes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer res.Body.Close()
+	if diags := utils.CheckError(res, "Unable to update cross-cluster apikey"); diags.HasError() {
+		return diags
+	}
+	return nil
+}
+
+// perform sends a JSON request to the APIs which aren't part of the 7.x client.
+func perform(ctx context.Context, esClient *elasticsearch.Client, method, path string, body []byte) (*esapi.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	return &esapi.Response{StatusCode: res.StatusCode, Header: res.Header, Body: res.Body}, nil
+}
+
+func GetApiKey(ctx context.Context, apiClient *clients.ApiClient, id string) (*models.ApiKeyResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	esClient, err := apiClient.GetESClient()
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 	req := esClient.Security.GetAPIKey.WithID(id)
-	res, err := esClient.Security.GetAPIKey(req)
+	res, err := esClient.Security.GetAPIKey(req, esClient.Security.GetAPIKey.WithContext(ctx))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -366,7 +488,7 @@ func GetApiKey(apiClient *clients.ApiClient, id string) (*models.ApiKeyResponse,
 	return &apiKey, diags
 }
 
-func DeleteApiKey(apiClient *clients.ApiClient, id string) diag.Diagnostics {
+func DeleteApiKey(ctx context.Context, apiClient *clients.ApiClient, id string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	apiKeys := struct {
@@ -383,8 +505,8 @@ func DeleteApiKey(apiClient *clients.ApiClient, id string) diag.Diagnostics {
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, err := esClient.Security.InvalidateAPIKey(bytes.NewReader(apikeyBytes))
-	if err != nil && res.IsError() {
+	res, err := esClient.Security.InvalidateAPIKey(bytes.NewReader(apikeyBytes), esClient.Security.InvalidateAPIKey.WithContext(ctx))
+	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer res.Body.Close()
@@ -1,13 +1,11 @@
 package fleet
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet/fleetapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
@@ -15,12 +13,19 @@ import (
 
 // Config is the configuration for the fleet client.
 type Config struct {
-	URL      string
-	Username string
-	Password string
-	APIKey   string
-	Insecure bool
-	CACerts  []string
+	URL         string
+	Username    string
+	Password    string
+	APIKey      string
+	BearerToken string
+	Insecure    bool
+	CACerts     []string
+	CAData      string
+	CertFile    string
+	KeyFile     string
+	CertData    string
+	KeyData     string
+	Retry       utils.RetryPolicy
 }
 
 // Client provides an API client for Elastic Fleet.
@@ -32,25 +37,23 @@ type Client struct {
 
 // NewClient creates a new Elastic Fleet API client.
 func NewClient(cfg Config) (*Client, error) {
-	var caCertPool *x509.CertPool
-	if len(cfg.CACerts) > 0 {
-		caCertPool = x509.NewCertPool()
-		for _, certFile := range cfg.CACerts {
-			certData, err := os.ReadFile(certFile)
-			if err != nil {
-				return nil, fmt.Errorf("unable to open CA certificate file %q: %w", certFile, err)
-			}
-			_ = caCertPool.AppendCertsFromPEM(certData)
-		}
+	tlsConfig, err := utils.TLSSettings{
+		Insecure: cfg.Insecure,
+		CACerts:  cfg.CACerts,
+		CAData:   cfg.CAData,
+		CertFile: cfg.CertFile,
+		KeyFile:  cfg.KeyFile,
+		CertData: cfg.CertData,
+		KeyData:  cfg.KeyData,
+	}.ClientConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	var roundTripper http.RoundTripper = &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: cfg.Insecure,
-			RootCAs:            caCertPool,
-		},
-	}
+	roundTripper := utils.NewRetryTransport("Fleet", cfg.Retry, cassette.Transport(&http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}))
 
 	if logging.IsDebugOrHigher() {
 		roundTripper = utils.NewDebugTransport("Fleet", roundTripper)
@@ -94,13 +97,14 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Header.Add("kbn-xsrf", "true")
 	}
 
-	if t.Username != "" {
+	switch {
+	case t.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+t.APIKey)
+	case t.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+t.BearerToken)
+	case t.Username != "":
 		req.SetBasicAuth(t.Username, t.Password)
 	}
 
-	if t.APIKey != "" {
-		req.Header.Add("Authorization", "Bearer "+t.APIKey)
-	}
-
 	return t.next.RoundTrip(req)
 }
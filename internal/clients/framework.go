@@ -0,0 +1,200 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewApiClientFromFramework builds the provider level client from the plugin framework provider configuration.
+func NewApiClientFromFramework(ctx context.Context, cfg tfsdk.Config, version string) (*ApiClient, diag.Diagnostics) {
+	config, err := newFrameworkConfig(cfg.Raw)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return newApiClient(config, version)
+}
+
+// NewApiClientFromFrameworkResource returns the client used by a plugin framework Fleet resource. The provider
+// client is returned unless the resource defines its own fleet_connection block.
+func NewApiClientFromFrameworkResource(ctx context.Context, fleetConnection types.List, defaultClient *ApiClient) (*ApiClient, diag.Diagnostics) {
+	raw, err := fleetConnection.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	value, err := fromFrameworkValue(raw, providerSchema.GetFleetConnectionSchema(fleetConnectionKey))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return newResourceApiClient(frameworkConfig{fleetConnectionKey: value}, defaultClient)
+}
+
+// frameworkConfig holds the provider configuration in the same shape as schema.ResourceData,
+// so that it can be passed to the same client builders as the SDKv2 provider configuration.
+type frameworkConfig map[string]interface{}
+
+func (c frameworkConfig) GetOk(key string) (interface{}, bool) {
+	value, ok := c[key]
+	if !ok {
+		return nil, false
+	}
+	if list, isList := value.([]interface{}); isList && len(list) == 0 {
+		return value, false
+	}
+	return value, true
+}
+
+// connectionSchemas returns the SDKv2 schema for each of the provider configuration blocks.
+// It's used as the source of truth for the shape and the defaults of the converted values.
+func connectionSchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		esKey:    providerSchema.GetEsConnectionSchema(esKey, true),
+		"kibana": providerSchema.GetKibanaConnectionSchema("kibana"),
+		"fleet":  providerSchema.GetFleetConnectionSchema("fleet"),
+		"retry":  providerSchema.GetRetrySchema(),
+	}
+}
+
+func newFrameworkConfig(raw tftypes.Value) (frameworkConfig, error) {
+	values, err := fromFrameworkObject(raw, connectionSchemas())
+	if err != nil {
+		return nil, err
+	}
+	return frameworkConfig(values), nil
+}
+
+func fromFrameworkObject(raw tftypes.Value, s map[string]*schema.Schema) (map[string]interface{}, error) {
+	attrs := map[string]tftypes.Value{}
+	if !raw.IsNull() && raw.IsKnown() {
+		if err := raw.As(&attrs); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[string]interface{}, len(s))
+	for name, attrSchema := range s {
+		value, err := fromFrameworkValue(attrs[name], attrSchema)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read provider configuration attribute %q: %w", name, err)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+func fromFrameworkValue(raw tftypes.Value, s *schema.Schema) (interface{}, error) {
+	// A zero tftypes.Value is neither null nor known, and is treated the same as an unset attribute.
+	if raw.IsNull() || !raw.IsKnown() {
+		return defaultValue(s)
+	}
+
+	switch s.Type {
+	case schema.TypeString:
+		var value string
+		err := raw.As(&value)
+		return value, err
+	case schema.TypeBool:
+		var value bool
+		err := raw.As(&value)
+		return value, err
+	case schema.TypeInt:
+		var value big.Float
+		if err := raw.As(&value); err != nil {
+			return nil, err
+		}
+		i, _ := value.Int64()
+		return int(i), nil
+	case schema.TypeFloat:
+		var value big.Float
+		if err := raw.As(&value); err != nil {
+			return nil, err
+		}
+		f, _ := value.Float64()
+		return f, nil
+	case schema.TypeList:
+		var elems []tftypes.Value
+		if err := raw.As(&elems); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			var value interface{}
+			var err error
+			switch elemSchema := s.Elem.(type) {
+			case *schema.Resource:
+				value, err = fromFrameworkObject(elem, elemSchema.Schema)
+			case *schema.Schema:
+				value, err = fromFrameworkValue(elem, elemSchema)
+			default:
+				err = fmt.Errorf("unsupported list element %T", s.Elem)
+			}
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute type %s", s.Type)
+	}
+}
+
+// defaultValue mirrors how schema.ResourceData resolves an unset attribute.
+func defaultValue(s *schema.Schema) (interface{}, error) {
+	value := s.Default
+	if s.DefaultFunc != nil {
+		v, err := s.DefaultFunc()
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			value = v
+		}
+	}
+
+	switch s.Type {
+	case schema.TypeString:
+		if value == nil {
+			return "", nil
+		}
+		return value, nil
+	case schema.TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+		return false, nil
+	case schema.TypeInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case string:
+			return strconv.Atoi(v)
+		}
+		return 0, nil
+	case schema.TypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+		return 0.0, nil
+	case schema.TypeList:
+		return []interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute type %s", s.Type)
+	}
+}
@@ -0,0 +1,49 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newFrameworkConfig(t *testing.T) {
+	t.Setenv("ELASTICSEARCH_PASSWORD", "from-env")
+
+	stringList := tftypes.List{ElementType: tftypes.String}
+	esType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"username":  tftypes.String,
+		"password":  tftypes.String,
+		"endpoints": stringList,
+		"insecure":  tftypes.Bool,
+	}}
+	configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		esKey: tftypes.List{ElementType: esType},
+	}}
+
+	raw := tftypes.NewValue(configType, map[string]tftypes.Value{
+		esKey: tftypes.NewValue(tftypes.List{ElementType: esType}, []tftypes.Value{
+			tftypes.NewValue(esType, map[string]tftypes.Value{
+				"username":  tftypes.NewValue(tftypes.String, "elastic"),
+				"password":  tftypes.NewValue(tftypes.String, nil),
+				"endpoints": tftypes.NewValue(stringList, []tftypes.Value{tftypes.NewValue(tftypes.String, "http://localhost:9200")}),
+				"insecure":  tftypes.NewValue(tftypes.Bool, true),
+			}),
+		}),
+	})
+
+	config, err := newFrameworkConfig(raw)
+	require.NoError(t, err)
+
+	_, ok := config.GetOk("kibana")
+	require.False(t, ok, "an unset block should not be reported as set")
+
+	es, ok := config.GetOk(esKey)
+	require.True(t, ok)
+	esConfig := es.([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "elastic", esConfig["username"])
+	require.Equal(t, "from-env", esConfig["password"])
+	require.Equal(t, []interface{}{"http://localhost:9200"}, esConfig["endpoints"])
+	require.Equal(t, true, esConfig["insecure"])
+	require.Equal(t, "", esConfig["api_key"])
+}
@@ -3,11 +3,13 @@ package kibana
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/elastic/terraform-provider-elasticstack/generated/alerting"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
@@ -62,6 +64,17 @@ func ruleActionsToActionsInner(ruleActions []models.AlertingRuleAction) []alerti
 	return actions
 }
 
+// ruleErrorPath reports request body validation errors on the rule attributes.
+var ruleErrorPath = utils.RequestBodyErrorPath(func(field string) cty.Path {
+	switch name, _, _ := strings.Cut(field, "."); name {
+	case "schedule":
+		return cty.GetAttrPath("interval")
+	case "name", "consumer", "notify_when", "params", "rule_type_id", "actions", "tags", "throttle":
+		return cty.GetAttrPath(name)
+	}
+	return nil
+})
+
 func CreateAlertingRule(ctx context.Context, apiClient *clients.ApiClient, rule models.AlertingRule) (*models.AlertingRule, diag.Diagnostics) {
 	client, err := apiClient.GetAlertingClient()
 	if err != nil {
@@ -91,7 +104,7 @@ func CreateAlertingRule(ctx context.Context, apiClient *clients.ApiClient, rule
 	}
 
 	defer res.Body.Close()
-	return ruleResponseToModel(rule.SpaceID, ruleRes), utils.CheckHttpError(res, "Unabled to create alerting rule")
+	return ruleResponseToModel(rule.SpaceID, ruleRes), utils.CheckHttpError(res, "Unabled to create alerting rule", ruleErrorPath)
 }
 
 func UpdateAlertingRule(ctx context.Context, apiClient *clients.ApiClient, rule models.AlertingRule) (*models.AlertingRule, diag.Diagnostics) {
@@ -120,7 +133,7 @@ func UpdateAlertingRule(ctx context.Context, apiClient *clients.ApiClient, rule
 	}
 
 	defer res.Body.Close()
-	if diags := utils.CheckHttpError(res, "Unable to update alerting rule"); diags.HasError() {
+	if diags := utils.CheckHttpError(res, "Unable to update alerting rule", ruleErrorPath); diags.HasError() {
 		return nil, diags
 	}
 
@@ -170,6 +183,35 @@ func GetAlertingRule(ctx context.Context, apiClient *clients.ApiClient, id, spac
 	return ruleResponseToModel(spaceID, ruleRes), utils.CheckHttpError(res, "Unabled to get alerting rule")
 }
 
+// ListAlertingRules returns all the alerting rules of the space.
+func ListAlertingRules(ctx context.Context, apiClient *clients.ApiClient, spaceID string) ([]models.AlertingRule, diag.Diagnostics) {
+	client, err := apiClient.GetAlertingClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	ctxWithAuth := apiClient.SetAlertingAuthContext(ctx)
+	rules := []models.AlertingRule{}
+	for page := int32(1); ; page++ {
+		findRes, res, err := client.FindRules(ctxWithAuth, spaceID).Page(page).PerPage(100).Execute()
+		if err != nil && res == nil {
+			return nil, diag.FromErr(err)
+		}
+		diags := utils.CheckHttpError(res, "Unable to list alerting rules")
+		res.Body.Close()
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		for i := range findRes.Data {
+			rules = append(rules, *ruleResponseToModel(spaceID, &findRes.Data[i]))
+		}
+		if len(findRes.Data) == 0 || len(rules) >= int(unwrapOptionalField(findRes.Total)) {
+			return rules, nil
+		}
+	}
+}
+
 func DeleteAlertingRule(ctx context.Context, apiClient *clients.ApiClient, ruleId string, spaceId string) diag.Diagnostics {
 	client, err := apiClient.GetAlertingClient()
 	if err != nil {
@@ -49,7 +49,7 @@ func ResourceScript() *schema.Resource {
 	}
 	utils.AddConnectionSchema(scriptSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Creates or updates a stored script or search template. See https://www.elastic.co/guide/en/elasticsearch/reference/current/create-stored-script-api.html",
 
 		CreateContext: resourceScriptPut,
@@ -62,7 +62,7 @@ func ResourceScript() *schema.Resource {
 		},
 
 		Schema: scriptSchema,
-	}
+	})
 }
 
 func resourceScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
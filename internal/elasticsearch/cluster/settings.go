@@ -69,7 +69,7 @@ func ResourceSettings() *schema.Resource {
 
 	utils.AddConnectionSchema(settingsSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Updates cluster-wide settings. If the Elasticsearch security features are enabled, you must have the manage cluster privilege to use this API. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-update-settings.html",
 
 		CreateContext: resourceClusterSettingsPut,
@@ -82,7 +82,7 @@ func ResourceSettings() *schema.Resource {
 		},
 
 		Schema: settingsSchema,
-	}
+	})
 }
 
 func resourceClusterSettingsPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -10,6 +10,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -138,7 +139,7 @@ func ResourceSlm() *schema.Resource {
 
 	utils.AddConnectionSchema(slmSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(versionutils.ResourceNotOnServerless(&schema.Resource{
 		Description: "Creates or updates a snapshot lifecycle policy. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/slm-api-put-policy.html",
 
 		CreateContext: resourceSlmPut,
@@ -151,7 +152,7 @@ func ResourceSlm() *schema.Resource {
 		},
 
 		Schema: slmSchema,
-	}
+	}))
 }
 
 func resourceSlmPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -305,7 +305,7 @@ func ResourceSnapshotRepository() *schema.Resource {
 
 	utils.AddConnectionSchema(snapRepoSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Registers or updates a snapshot repository. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/put-snapshot-repo-api.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/snapshots-register-repository.html",
 
 		CreateContext: resourceSnapRepoPut,
@@ -318,7 +318,7 @@ func ResourceSnapshotRepository() *schema.Resource {
 		},
 
 		Schema: snapRepoSchema,
-	}
+	})
 }
 
 func resourceSnapRepoPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -5,11 +5,13 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataSourceSnapRepoFs(t *testing.T) {
+	cassette.Start(t)
 	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -55,6 +57,7 @@ data "elasticstack_elasticsearch_snapshot_repository" "test_fs_repo" {
 }
 
 func TestAccDataSourceSnapRepoUrl(t *testing.T) {
+	cassette.Start(t)
 	name := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -75,7 +75,7 @@ func ResourceEnrichPolicy() *schema.Resource {
 
 	utils.AddConnectionSchema(policySchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Managing Elasticsearch enrich policies, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/enrich-apis.html",
 
 		CreateContext: resourceEnrichPolicyPut,
@@ -88,7 +88,7 @@ func ResourceEnrichPolicy() *schema.Resource {
 		},
 
 		Schema: policySchema,
-	}
+	})
 }
 
 func resourceEnrichPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
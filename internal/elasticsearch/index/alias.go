@@ -0,0 +1,269 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceIndexAlias() *schema.Resource {
+	aliasSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Name of the alias.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 255),
+				validation.StringNotInSlice([]string{".", ".."}, true),
+				validation.StringMatch(regexp.MustCompile(`^[^-_+]`), "cannot start with -, _, +"),
+				validation.StringMatch(regexp.MustCompile(`^[a-z0-9!$%&'()+.;=@[\]^{}~_-]+$`), "must contain lower case alphanumeric characters and selected punctuation, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-add-alias.html#add-alias-api-path-params"),
+			),
+		},
+		"is_hidden": {
+			Description: "If true, the alias is hidden. It applies to all the indices of the alias.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"index": {
+			Description: "Indices or data streams the alias points to. The alias is removed from the indices and data streams which aren't listed.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Name of the index or data stream.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"filter": {
+						Description:      "Query used to limit documents the alias can access.",
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "",
+						DiffSuppressFunc: utils.DiffJsonSuppress,
+						ValidateFunc:     validation.StringIsJSON,
+					},
+					"index_routing": {
+						Description: "Value used to route indexing operations to a specific shard. If specified, this overwrites the `routing` value for indexing operations. Not supported by data streams.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "",
+					},
+					"is_write_index": {
+						Description: "If true, the index or data stream is the write index for the alias. At most one member of the alias can be the write index.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+					},
+					"routing": {
+						Description: "Value used to route indexing and search operations to a specific shard. Not supported by data streams.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "",
+					},
+					"search_routing": {
+						Description: "Value used to route search operations to a specific shard. If specified, this overwrites the routing value for search operations. Not supported by data streams.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "",
+					},
+				},
+			},
+		},
+	}
+
+	utils.AddConnectionSchema(aliasSchema)
+
+	return utils.WithTimeouts(&schema.Resource{
+		Description: "Manages an alias pointing to several indices or data streams, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/aliases.html",
+
+		CreateContext: resourceIndexAliasPut,
+		UpdateContext: resourceIndexAliasPut,
+		ReadContext:   resourceIndexAliasRead,
+		DeleteContext: resourceIndexAliasDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceIndexAliasDiff,
+
+		Schema: aliasSchema,
+	})
+}
+
+// resourceIndexAliasDiff fails the plan when several members are the write index, which Elasticsearch rejects.
+func resourceIndexAliasDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var writeIndices []string
+	for _, m := range d.Get("index").(*schema.Set).List() {
+		member := m.(map[string]interface{})
+		if member["is_write_index"].(bool) {
+			writeIndices = append(writeIndices, member["name"].(string))
+		}
+	}
+	if len(writeIndices) > 1 {
+		return fmt.Errorf("only one index can be the write index of the alias, got %v", writeIndices)
+	}
+	return nil
+}
+
+// resourceIndexAliasPut adds the alias to the configured indices and removes it from the other ones in a single
+// request, so that searches and writes through the alias never see an intermediate state.
+func resourceIndexAliasPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	aliasName := d.Get("name").(string)
+	id, diags := client.ID(ctx, aliasName)
+	if diags.HasError() {
+		return diags
+	}
+
+	oldMembers, newMembers := d.GetChange("index")
+	configured := make(map[string]bool)
+	var actions []models.AliasAction
+	var removed []string
+	for _, m := range newMembers.(*schema.Set).List() {
+		member := map[string]interface{}{"is_hidden": d.Get("is_hidden").(bool)}
+		for k, v := range m.(map[string]interface{}) {
+			member[k] = v
+		}
+		alias, diags := ExpandIndexAlias(member)
+		if diags.HasError() {
+			return diags
+		}
+		index := member["name"].(string)
+		configured[index] = true
+		// adding the alias again replaces its definition on the index
+		actions = append(actions, models.AliasAction{Add: &models.AliasActionParams{Index: index, Alias: aliasName, IndexAlias: *alias}})
+	}
+	for _, m := range oldMembers.(*schema.Set).List() {
+		index := m.(map[string]interface{})["name"].(string)
+		if !configured[index] {
+			removed = append(removed, index)
+		}
+	}
+	removeActions, diags := aliasRemoveActions(ctx, client, aliasName, removed)
+	if diags.HasError() {
+		return diags
+	}
+	actions = append(actions, removeActions...)
+
+	if diags := elasticsearch.UpdateAliases(ctx, client, actions); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceIndexAliasRead(ctx, d, meta)
+}
+
+func resourceIndexAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	aliasName := compId.ResourceId
+
+	members, diags := elasticsearch.GetAlias(ctx, client, aliasName)
+	if members == nil && diags == nil {
+		tflog.Warn(ctx, fmt.Sprintf(`Alias "%s" not found, removing from state`, aliasName))
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("name", aliasName); err != nil {
+		return diag.FromErr(err)
+	}
+	var isHidden bool
+	indices := make([]interface{}, 0, len(members))
+	for index, alias := range members {
+		// Elasticsearch returns the routing as the index and search routings
+		if alias.Routing == "" && alias.IndexRouting != "" && alias.IndexRouting == alias.SearchRouting {
+			alias.Routing, alias.IndexRouting, alias.SearchRouting = alias.IndexRouting, "", ""
+		}
+		member, diags := FlattenIndexAlias(index, alias)
+		if diags.HasError() {
+			return diags
+		}
+		delete(member.(map[string]interface{}), "is_hidden")
+		isHidden = isHidden || alias.IsHidden
+		indices = append(indices, member)
+	}
+	if err := d.Set("index", indices); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_hidden", isHidden); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceIndexAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	var indices []string
+	for _, m := range d.Get("index").(*schema.Set).List() {
+		indices = append(indices, m.(map[string]interface{})["name"].(string))
+	}
+	actions, diags := aliasRemoveActions(ctx, client, compId.ResourceId, indices)
+	if diags.HasError() || len(actions) == 0 {
+		return diags
+	}
+	if diags := elasticsearch.UpdateAliases(ctx, client, actions); diags.HasError() {
+		return diags
+	}
+	return diags
+}
+
+// aliasRemoveActions returns the actions removing the alias from the given indices. The indices which no longer hold
+// the alias, e.g. because they were deleted outside of Terraform, are skipped as Elasticsearch would reject the request.
+func aliasRemoveActions(ctx context.Context, client *clients.ApiClient, aliasName string, indices []string) ([]models.AliasAction, diag.Diagnostics) {
+	if len(indices) == 0 {
+		return nil, nil
+	}
+	members, diags := elasticsearch.GetAlias(ctx, client, aliasName)
+	if diags.HasError() {
+		return nil, diags
+	}
+	var actions []models.AliasAction
+	for _, index := range indices {
+		if _, ok := members[index]; !ok {
+			tflog.Warn(ctx, fmt.Sprintf(`Index "%s" no longer holds alias "%s", skipping its removal`, index, aliasName))
+			continue
+		}
+		actions = append(actions, models.AliasAction{Remove: &models.AliasActionParams{Index: index, Alias: aliasName}})
+	}
+	return actions, nil
+}
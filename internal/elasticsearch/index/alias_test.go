@@ -0,0 +1,206 @@
+package index_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccResourceIndexAlias(t *testing.T) {
+	cassette.Start(t)
+	aliasName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexAliasDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				// the indices aren't managed by Terraform, which would otherwise track the alias too
+				PreConfig: func() { createUnmanagedIndices(t, aliasName+"-1", aliasName+"-2", aliasName+"-3") },
+				Config:    testAccResourceIndexAliasCreate(aliasName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_alias.test_alias", "name", aliasName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_alias.test_alias", "index.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test_alias", "index.*", map[string]string{
+						"name":           aliasName + "-1",
+						"is_write_index": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test_alias", "index.*", map[string]string{
+						"name":    aliasName + "-2",
+						"routing": "shard-1",
+						"filter":  `{"term":{"user.id":"developer"}}`,
+					}),
+				),
+			},
+			{
+				Config: testAccResourceIndexAliasUpdate(aliasName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_alias.test_alias", "index.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test_alias", "index.*", map[string]string{
+						"name":           aliasName + "-2",
+						"is_write_index": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index_alias.test_alias", "index.*", map[string]string{
+						"name":           aliasName + "-3",
+						"is_write_index": "true",
+					}),
+				),
+			},
+			{
+				ResourceName:      "elasticstack_elasticsearch_index_alias.test_alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceIndexAliasDiff(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "logs",
+		"index": []interface{}{
+			map[string]interface{}{"name": "logs-1", "is_write_index": true},
+			map[string]interface{}{"name": "logs-2", "is_write_index": true},
+		},
+	})
+	_, err := index.ResourceIndexAlias().SimpleDiff(context.Background(), nil, config, nil)
+	require.ErrorContains(t, err, "only one index can be the write index of the alias")
+}
+
+func TestResourceIndexAliasMissingIndex(t *testing.T) {
+	server := fake.NewServer(t)
+	server.Setenv(t)
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+	for _, name := range []string{"logs-1", "logs-2"} {
+		require.False(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: name}, &models.PutIndexParams{}).HasError())
+	}
+
+	r := index.ResourceIndexAlias()
+	plan := func(state *terraform.InstanceState, indices ...string) *schema.ResourceData {
+		members := make([]interface{}, 0, len(indices))
+		for _, name := range indices {
+			members = append(members, map[string]interface{}{"name": name})
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "logs", "index": members})
+		diff, err := r.SimpleDiff(ctx, state, config, client)
+		require.NoError(t, err)
+		d, err := schema.InternalMap(r.Schema).Data(state, diff)
+		require.NoError(t, err)
+		return d
+	}
+
+	d := plan(nil, "logs-1", "logs-2")
+	diags := r.CreateContext(ctx, d, client)
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, d.Id())
+
+	// the index removed from the configuration no longer exists
+	require.False(t, elasticsearch.DeleteIndex(ctx, client, "logs-2").HasError())
+	d = plan(d.State(), "logs-1")
+	diags = r.UpdateContext(ctx, d, client)
+	require.False(t, diags.HasError(), diags)
+	members, diags := elasticsearch.GetAlias(ctx, client, "logs")
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, members, 1)
+
+	// the last index of the alias no longer exists
+	require.False(t, elasticsearch.DeleteIndex(ctx, client, "logs-1").HasError())
+	diags = r.DeleteContext(ctx, d, client)
+	require.False(t, diags.HasError(), diags)
+}
+
+func createUnmanagedIndices(t *testing.T, names ...string) {
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+	for _, name := range names {
+		name := name
+		diags := elasticsearch.PutIndex(context.Background(), client, &models.Index{Name: name}, &models.PutIndexParams{})
+		require.False(t, diags.HasError(), diags)
+		t.Cleanup(func() { elasticsearch.DeleteIndex(context.Background(), client, name) })
+	}
+}
+
+func testAccResourceIndexAliasCreate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_alias" "test_alias" {
+  name = "%[1]s"
+
+  index {
+    name           = "%[1]s-1"
+    is_write_index = true
+  }
+
+  index {
+    name    = "%[1]s-2"
+    routing = "shard-1"
+    filter = jsonencode({
+      term = { "user.id" = "developer" }
+    })
+  }
+}
+	`, name)
+}
+
+func testAccResourceIndexAliasUpdate(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index_alias" "test_alias" {
+  name = "%[1]s"
+
+  index {
+    name = "%[1]s-2"
+  }
+
+  index {
+    name           = "%[1]s-3"
+    is_write_index = true
+  }
+}
+	`, name)
+}
+
+func checkResourceIndexAliasDestroy(s *terraform.State) error {
+	client, err := clients.NewAcceptanceTestingClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "elasticstack_elasticsearch_index_alias" {
+			continue
+		}
+		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
+
+		members, diags := elasticsearch.GetAlias(context.Background(), client, compId.ResourceId)
+		if diags.HasError() {
+			return fmt.Errorf("Unable to get the alias: %v", diags)
+		}
+		if members != nil {
+			return fmt.Errorf("Alias (%s) still exists", compId.ResourceId)
+		}
+	}
+	return nil
+}
@@ -118,7 +118,7 @@ func ResourceComponentTemplate() *schema.Resource {
 
 	utils.AddConnectionSchema(componentTemplateSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Creates or updates a component template. Component templates are building blocks for constructing index templates that specify index mappings, settings, and aliases. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-component-template.html",
 
 		CreateContext: resourceComponentTemplatePut,
@@ -131,7 +131,7 @@ func ResourceComponentTemplate() *schema.Resource {
 		},
 
 		Schema: componentTemplateSchema,
-	}
+	})
 }
 
 func resourceComponentTemplatePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
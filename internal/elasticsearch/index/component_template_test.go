@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -13,25 +15,41 @@ import (
 
 func TestAccResourceComponentTemplate(t *testing.T) {
 	// generate a random username
+	cassette.Start(t)
 	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
 		CheckDestroy:             checkResourceComponentTemplateDestroy,
 		ProtoV5ProviderFactories: acctest.Providers,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceComponentTemplateCreate(templateName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "name", templateName),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "template.0.alias.0.name", "my_template_test"),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "template.0.settings", `{"index":{"number_of_shards":"3"}}`),
-				),
-			},
-		},
+		Steps:                    testAccResourceComponentTemplateSteps(templateName),
 	})
 }
 
+func TestResourceComponentTemplate(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceComponentTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceComponentTemplateSteps("template"),
+	})
+}
+
+func testAccResourceComponentTemplateSteps(templateName string) []resource.TestStep {
+	return []resource.TestStep{
+		{
+			Config: testAccResourceComponentTemplateCreate(templateName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "name", templateName),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "template.0.alias.0.name", "my_template_test"),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_component_template.test", "template.0.settings", `{"index":{"number_of_shards":"3"}}`),
+			),
+		},
+	}
+}
+
 func testAccResourceComponentTemplateCreate(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
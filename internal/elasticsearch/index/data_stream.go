@@ -102,7 +102,7 @@ func ResourceDataStream() *schema.Resource {
 
 	utils.AddConnectionSchema(dataStreamSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Managing Elasticsearch data streams, see: https://www.elastic.co/guide/en/elasticsearch/reference/current/data-stream-apis.html",
 
 		CreateContext: resourceDataStreamPut,
@@ -115,7 +115,7 @@ func ResourceDataStream() *schema.Resource {
 		},
 
 		Schema: dataStreamSchema,
-	}
+	})
 }
 
 func resourceDataStreamPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -10,6 +10,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -98,7 +99,7 @@ func ResourceIlm() *schema.Resource {
 
 	utils.AddConnectionSchema(ilmSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(versionutils.ResourceNotOnServerless(ilmVersionRequirements.Apply(&schema.Resource{
 		Description: "Creates or updates lifecycle policy. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-put-lifecycle.html and https://www.elastic.co/guide/en/elasticsearch/reference/current/ilm-index-lifecycle.html",
 
 		CreateContext: resourceIlmPut,
@@ -111,7 +112,7 @@ func ResourceIlm() *schema.Resource {
 		},
 
 		Schema: ilmSchema,
-	}
+	})))
 }
 
 var supportedActions = map[string]*schema.Schema{
@@ -129,7 +130,7 @@ var supportedActions = map[string]*schema.Schema{
 					Default:     0,
 				},
 				"total_shards_per_node": {
-					Description: "The maximum number of shards for the index on a single Elasticsearch node. Defaults to `-1` (unlimited). Supported from Elasticsearch version **7.16**",
+					Description: "The maximum number of shards for the index on a single Elasticsearch node. Defaults to `-1` (unlimited).",
 					Type:        schema.TypeInt,
 					Optional:    true,
 					Default:     -1,
@@ -274,12 +275,12 @@ var supportedActions = map[string]*schema.Schema{
 					Optional:    true,
 				},
 				"min_age": {
-					Description: "Prevents rollover until after the minimum elapsed time from index creation is reached. Supported from Elasticsearch version **8.4**",
+					Description: "Prevents rollover until after the minimum elapsed time from index creation is reached.",
 					Type:        schema.TypeString,
 					Optional:    true,
 				},
 				"min_docs": {
-					Description: "Prevents rollover until after the specified minimum number of documents is reached. Supported from Elasticsearch version **8.4**",
+					Description: "Prevents rollover until after the specified minimum number of documents is reached.",
 					Type:        schema.TypeInt,
 					Optional:    true,
 				},
@@ -289,12 +290,12 @@ var supportedActions = map[string]*schema.Schema{
 					Optional:    true,
 				},
 				"min_primary_shard_size": {
-					Description: "Prevents rollover until the largest primary shard in the index reaches a certain size. Supported from Elasticsearch version **8.4**",
+					Description: "Prevents rollover until the largest primary shard in the index reaches a certain size.",
 					Type:        schema.TypeString,
 					Optional:    true,
 				},
 				"min_primary_shard_docs": {
-					Description: "Prevents rollover until the largest primary shard in the index reaches a certain number of documents. Supported from Elasticsearch version **8.4**",
+					Description: "Prevents rollover until the largest primary shard in the index reaches a certain number of documents.",
 					Type:        schema.TypeInt,
 					Optional:    true,
 				},
@@ -424,6 +425,14 @@ func resourceIlmPut(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diags
 	}
 
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := ilmVersionRequirements.Check(d, serverVersion, serverFlavor); diags.HasError() {
+		return diags
+	}
+
 	policy, diags := expandIlmPolicy(d, serverVersion)
 	if diags.HasError() {
 		return diags
@@ -455,7 +464,7 @@ func expandIlmPolicy(d *schema.ResourceData, serverVersion *version.Version) (*m
 
 	for _, ph := range supportedIlmPhases {
 		if v, ok := d.GetOk(ph); ok {
-			phase, diags := expandPhase(v.([]interface{})[0].(map[string]interface{}), serverVersion)
+			phase, diags := expandPhase(ph, v.([]interface{})[0].(map[string]interface{}), serverVersion)
 			if diags.HasError() {
 				return nil, diags
 			}
@@ -467,7 +476,7 @@ func expandIlmPolicy(d *schema.ResourceData, serverVersion *version.Version) (*m
 	return &policy, diags
 }
 
-func expandPhase(p map[string]interface{}, serverVersion *version.Version) (*models.Phase, diag.Diagnostics) {
+func expandPhase(phaseName string, p map[string]interface{}, serverVersion *version.Version) (*models.Phase, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var phase models.Phase
 
@@ -481,44 +490,44 @@ func expandPhase(p map[string]interface{}, serverVersion *version.Version) (*mod
 		if a := action.([]interface{}); len(a) > 0 {
 			switch actionName {
 			case "allocate":
-				actions[actionName], diags = expandAction(a, serverVersion, "number_of_replicas", "total_shards_per_node", "include", "exclude", "require")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "number_of_replicas", "total_shards_per_node", "include", "exclude", "require")
 			case "delete":
-				actions[actionName], diags = expandAction(a, serverVersion, "delete_searchable_snapshot")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "delete_searchable_snapshot")
 			case "forcemerge":
-				actions[actionName], diags = expandAction(a, serverVersion, "max_num_segments", "index_codec")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "max_num_segments", "index_codec")
 			case "freeze":
 				if a[0] != nil {
 					ac := a[0].(map[string]interface{})
 					if ac["enabled"].(bool) {
-						actions[actionName], diags = expandAction(a, serverVersion)
+						actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName))
 					}
 				}
 			case "migrate":
-				actions[actionName], diags = expandAction(a, serverVersion, "enabled")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "enabled")
 			case "readonly":
 				if a[0] != nil {
 					ac := a[0].(map[string]interface{})
 					if ac["enabled"].(bool) {
-						actions[actionName], diags = expandAction(a, serverVersion)
+						actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName))
 					}
 				}
 			case "rollover":
-				actions[actionName], diags = expandAction(a, serverVersion, "max_age", "max_docs", "max_size", "max_primary_shard_size", "min_age", "min_docs", "min_size", "min_primary_shard_size", "min_primary_shard_docs")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "max_age", "max_docs", "max_size", "max_primary_shard_size", "min_age", "min_docs", "min_size", "min_primary_shard_size", "min_primary_shard_docs")
 			case "searchable_snapshot":
-				actions[actionName], diags = expandAction(a, serverVersion, "snapshot_repository", "force_merge_index")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "snapshot_repository", "force_merge_index")
 			case "set_priority":
-				actions[actionName], diags = expandAction(a, serverVersion, "priority")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "priority")
 			case "shrink":
-				actions[actionName], diags = expandAction(a, serverVersion, "number_of_shards", "max_primary_shard_size")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "number_of_shards", "max_primary_shard_size")
 			case "unfollow":
 				if a[0] != nil {
 					ac := a[0].(map[string]interface{})
 					if ac["enabled"].(bool) {
-						actions[actionName], diags = expandAction(a, serverVersion)
+						actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName))
 					}
 				}
 			case "wait_for_snapshot":
-				actions[actionName], diags = expandAction(a, serverVersion, "policy")
+				actions[actionName], diags = expandAction(a, serverVersion, fmt.Sprintf("%s.0.%s.0", phaseName, actionName), "policy")
 			default:
 				diags = append(diags, diag.Diagnostic{
 					Severity: diag.Error,
@@ -535,22 +544,27 @@ func expandPhase(p map[string]interface{}, serverVersion *version.Version) (*mod
 }
 
 var RolloverMinConditionsMinSupportedVersion = version.Must(version.NewVersion("8.4.0"))
+
+var ilmVersionRequirements = func() versionutils.Requirements {
+	requirements := versionutils.Requirements{}
+	for _, phase := range []string{"warm", "cold"} {
+		requirements[phase+".0.allocate.0.total_shards_per_node"] = versionutils.Min("7.16.0").WithDefault(-1)
+	}
+	for _, setting := range []string{"min_age", "min_docs", "min_size", "min_primary_shard_size", "min_primary_shard_docs"} {
+		requirements["hot.0.rollover.0."+setting] = versionutils.Requirement{MinVersion: RolloverMinConditionsMinSupportedVersion}
+	}
+	return requirements
+}()
+
 var ilmActionSettingOptions = map[string]struct {
 	skipEmptyCheck bool
-	def            interface{}
-	minVersion     *version.Version
 }{
-	"number_of_replicas":     {skipEmptyCheck: true},
-	"total_shards_per_node":  {skipEmptyCheck: true, def: -1, minVersion: version.Must(version.NewVersion("7.16.0"))},
-	"priority":               {skipEmptyCheck: true},
-	"min_age":                {def: "", minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_docs":               {def: 0, minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_size":               {def: "", minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_primary_shard_size": {def: "", minVersion: RolloverMinConditionsMinSupportedVersion},
-	"min_primary_shard_docs": {def: 0, minVersion: RolloverMinConditionsMinSupportedVersion},
+	"number_of_replicas":    {skipEmptyCheck: true},
+	"total_shards_per_node": {skipEmptyCheck: true},
+	"priority":              {skipEmptyCheck: true},
 }
 
-func expandAction(a []interface{}, serverVersion *version.Version, settings ...string) (map[string]interface{}, diag.Diagnostics) {
+func expandAction(a []interface{}, serverVersion *version.Version, actionKey string, settings ...string) (map[string]interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	def := make(map[string]interface{})
 
@@ -559,11 +573,7 @@ func expandAction(a []interface{}, serverVersion *version.Version, settings ...s
 			if v, ok := action.(map[string]interface{})[setting]; ok && v != nil {
 				options := ilmActionSettingOptions[setting]
 
-				if options.minVersion != nil && options.minVersion.GreaterThan(serverVersion) {
-					if v != options.def {
-						return nil, diag.Errorf("[%s] is not supported in the target Elasticsearch server. Remove the setting from your module definition or set it to the default [%s] value", setting, options.def)
-					}
-
+				if !ilmVersionRequirements.Supports(actionKey+"."+setting, serverVersion) {
 					// This setting is not supported, and shouldn't be set in the ILM policy object
 					continue
 				}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,7 +15,8 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
-	"github.com/hashicorp/go-version"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -34,6 +36,15 @@ var (
 		"sort.order":                        schema.TypeSet,
 		"mapping.coerce":                    schema.TypeBool,
 	}
+	// closableStaticSettingsKeys are the static settings which can be updated on a closed index, the other ones being
+	// final.
+	closableStaticSettingsKeys = map[string]schema.ValueType{
+		"codec":                             schema.TypeString,
+		"load_fixed_bitset_filters_eagerly": schema.TypeBool,
+		"shard.check_on_startup":            schema.TypeString,
+		"mapping.coerce":                    schema.TypeBool,
+	}
+	analysisComponents   = []string{"analyzer", "tokenizer", "char_filter", "filter", "normalizer"}
 	dynamicsSettingsKeys = map[string]schema.ValueType{
 		"number_of_replicas":                     schema.TypeInt,
 		"auto_expand_replicas":                   schema.TypeString,
@@ -82,7 +93,38 @@ var (
 	allSettingsKeys = map[string]schema.ValueType{}
 )
 
-var includeTypeNameMinUnsupportedVersion = version.Must(version.NewVersion("8.0.0"))
+// indexErrorPaths reports mapping and setting errors on the attributes defining them.
+var indexErrorPaths = []utils.ErrorPathResolver{
+	utils.MappingsErrorPath(cty.GetAttrPath("mappings")),
+	utils.SettingErrorPath(func(setting string) cty.Path {
+		key := strings.TrimPrefix(setting, "index.")
+		if _, ok := allSettingsKeys[key]; ok {
+			return cty.GetAttrPath(utils.ConvertSettingsKeyToTFFieldKey(key))
+		}
+		if parts := strings.SplitN(key, ".", 3); len(parts) > 1 && parts[0] == "analysis" {
+			switch parts[1] {
+			case "analyzer", "tokenizer", "char_filter", "filter", "normalizer":
+				return cty.GetAttrPath("analysis_" + parts[1])
+			}
+		}
+		return cty.GetAttrPath("settings")
+	}),
+}
+
+var indexVersionRequirements = versionutils.Requirements{
+	"include_type_name": versionutils.Before("8.0.0"),
+
+	// shards and replicas are managed by Elasticsearch Serverless
+	"number_of_shards":                     versionutils.NotOnServerless(),
+	"number_of_routing_shards":             versionutils.NotOnServerless(),
+	"routing_partition_size":               versionutils.NotOnServerless(),
+	"shard_check_on_startup":               versionutils.NotOnServerless(),
+	"number_of_replicas":                   versionutils.NotOnServerless(),
+	"auto_expand_replicas":                 versionutils.NotOnServerless(),
+	"routing_allocation_enable":            versionutils.NotOnServerless(),
+	"routing_rebalance_enable":             versionutils.NotOnServerless(),
+	"unassigned_node_left_delayed_timeout": versionutils.NotOnServerless(),
+}
 
 func init() {
 	for k, v := range staticSettingsKeys {
@@ -127,8 +169,7 @@ func ResourceIndex() *schema.Resource {
 		},
 		"codec": {
 			Type:         schema.TypeString,
-			Description:  "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio. This can be set only on creation.",
-			ForceNew:     true,
+			Description:  "The `default` value compresses stored data with LZ4 compression, but this can be set to `best_compression` which uses DEFLATE for a higher compression ratio. Changing it replaces the index, unless `allow_close_for_static_updates` is set.",
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"best_compression"}, false),
 		},
@@ -140,14 +181,12 @@ func ResourceIndex() *schema.Resource {
 		},
 		"load_fixed_bitset_filters_eagerly": {
 			Type:        schema.TypeBool,
-			Description: "Indicates whether cached filters are pre-loaded for nested queries. This can be set only on creation.",
-			ForceNew:    true,
+			Description: "Indicates whether cached filters are pre-loaded for nested queries. Changing it replaces the index, unless `allow_close_for_static_updates` is set.",
 			Optional:    true,
 		},
 		"shard_check_on_startup": {
 			Type:         schema.TypeString,
-			Description:  "Whether or not shards should be checked for corruption before opening. When corruption is detected, it will prevent the shard from being opened. Accepts `false`, `true`, `checksum`.",
-			ForceNew:     true,
+			Description:  "Whether or not shards should be checked for corruption before opening. When corruption is detected, it will prevent the shard from being opened. Accepts `false`, `true`, `checksum`. Changing it replaces the index, unless `allow_close_for_static_updates` is set.",
 			Optional:     true,
 			ValidateFunc: validation.StringInSlice([]string{"false", "true", "checksum"}, false),
 		},
@@ -168,8 +207,7 @@ func ResourceIndex() *schema.Resource {
 		},
 		"mapping_coerce": {
 			Type:        schema.TypeBool,
-			Description: "Set index level coercion setting that is applied to all mapping types.",
-			ForceNew:    true,
+			Description: "Set index level coercion setting that is applied to all mapping types. Changing it replaces the index, unless `allow_close_for_static_updates` is set.",
 			Optional:    true,
 		},
 		// Dynamic settings that can be changed at runtime
@@ -395,8 +433,8 @@ func ResourceIndex() *schema.Resource {
 			Description: "Set the number of characters of the `_source` to include in the slowlog lines, `false` or `0` will skip logging the source entirely and setting it to `true` will log the entire source regardless of size. The original `_source` is reformatted by default to make sure that it fits on a single log line.",
 			Optional:    true,
 		},
-		// To change analyzer setting, the index must be closed, updated, and then reopened but it can't be handled in terraform.
-		// We raise error when they are tried to be updated instead of setting ForceNew not to have unexpected deletion.
+		// To change analyzer setting, the index must be closed, updated, and then reopened, which is only done with `allow_close_for_static_updates`.
+		// We raise error when they are tried to be updated otherwise instead of setting ForceNew not to have unexpected deletion.
 		"analysis_analyzer": {
 			Type:         schema.TypeString,
 			Description:  "A JSON string describing the analyzers applied to the index.",
@@ -526,10 +564,30 @@ If specified, this mapping can include: field names, [field data types](https://
 			},
 		},
 		"settings_raw": {
-			Description: "All raw settings fetched from the cluster.",
+			Description:      "Index settings as a JSON object, for the settings which aren't available as attributes, such as `index.mode` or `index.routing_path`. They're merged with the settings defined by the other attributes, and may be nested or flattened, with or without the `index.` prefix. Only the settings defined here are tracked for changes.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: utils.DiffIndexSettingSuppress,
+			ValidateFunc:     validation.StringIsJSON,
+		},
+		"mapping_change_strategy": {
+			Type:         schema.TypeString,
+			Description:  "How to apply a mapping change which can't be applied to the existing index, such as a field type change. `recreate` replaces the index, deleting its documents. `reindex` creates a new backing index with the new mappings and settings, copies the documents with the reindex API, and atomically moves the aliases to the new index before deleting the previous one: `name` then becomes an alias of the backing index. Defaults to `recreate`.",
+			Optional:     true,
+			Default:      "recreate",
+			ValidateFunc: validation.StringInSlice([]string{"recreate", "reindex"}, false),
+		},
+		"backing_index": {
 			Type:        schema.TypeString,
+			Description: "Name of the index storing the documents. It differs from `name`, which is then an alias of the backing index, once a mapping change has been applied with the `reindex` strategy.",
 			Computed:    true,
 		},
+		"allow_close_for_static_updates": {
+			Type:        schema.TypeBool,
+			Description: "Whether to close the index to update its static settings, such as `codec` or the `analysis_*` attributes, and reopen it once updated. The index can't be searched or written to until its primary shards are allocated again. Otherwise, the index is replaced when a static setting changes, and an `analysis_*` change fails the plan.",
+			Optional:    true,
+			Default:     false,
+		},
 		"deletion_protection": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -538,7 +596,7 @@ If specified, this mapping can include: field names, [field data types](https://
 		},
 		"include_type_name": {
 			Type:        schema.TypeBool,
-			Description: "If true, a mapping type is expected in the body of mappings. Defaults to false. Supported for Elasticsearch 7.x.",
+			Description: "If true, a mapping type is expected in the body of mappings. Defaults to false.",
 			Optional:    true,
 			Default:     false,
 		},
@@ -566,7 +624,7 @@ If specified, this mapping can include: field names, [field data types](https://
 
 	utils.AddConnectionSchema(indexSchema)
 
-	return &schema.Resource{
+	r := utils.WithTimeouts(indexVersionRequirements.Apply(&schema.Resource{
 		Description: "Creates Elasticsearch indices. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-create-index.html",
 
 		CreateContext: resourceIndexCreate,
@@ -598,31 +656,18 @@ If specified, this mapping can include: field names, [field data types](https://
 
 				// check the settings and import those as well
 				if index.Settings != nil {
-					for key, typ := range allSettingsKeys {
-						var value interface{}
-						if v, ok := index.Settings[key]; ok {
-							value = v
-						} else if v, ok := index.Settings["index."+key]; ok {
-							value = v
-						} else {
-							tflog.Warn(ctx, fmt.Sprintf("setting '%s' is not currently managed by terraform provider and has been ignored", key))
-							continue
-						}
-						switch typ {
-						case schema.TypeInt:
-							v, err := strconv.Atoi(value.(string))
-							if err != nil {
-								return nil, fmt.Errorf("failed to convert setting '%s' value %v to int: %w", key, value, err)
-							}
-							value = v
-						case schema.TypeBool:
-							v, err := strconv.ParseBool(value.(string))
-							if err != nil {
-								return nil, fmt.Errorf("failed to convert setting '%s' value %v to bool: %w", key, value, err)
-							}
-							value = v
+					settings, rawSettings, err := flattenIndexSettingsAttributes(index.Settings)
+					if err != nil {
+						return nil, err
+					}
+					for fieldKey, value := range settings {
+						if err := d.Set(fieldKey, value); err != nil {
+							return nil, err
 						}
-						if err := d.Set(utils.ConvertSettingsKeyToTFFieldKey(key), value); err != nil {
+					}
+					// the settings without an attribute are imported into `settings_raw`
+					if rawSettings != "" {
+						if err := d.Set("settings_raw", rawSettings); err != nil {
 							return nil, err
 						}
 					}
@@ -631,33 +676,15 @@ If specified, this mapping can include: field names, [field data types](https://
 			},
 		},
 
-		CustomizeDiff: customdiff.ForceNewIfChange("mappings", func(ctx context.Context, old, new, meta interface{}) bool {
-			o := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(old.(string))).Decode(&o); err != nil {
-				return true
-			}
-			n := make(map[string]interface{})
-			if err := json.NewDecoder(strings.NewReader(new.(string))).Decode(&n); err != nil {
-				return true
-			}
-			tflog.Trace(ctx, "mappings custom diff old = %+v new = %+v", o, n)
-
-			// if old defined we must check if the type of the existing fields were changed
-			if oldProps, ok := o["properties"]; ok {
-				newProps, ok := n["properties"]
-				// if the old has props but new one not, immediately force new resource
-				if !ok {
-					return true
-				}
-				return IsMappingForceNewRequired(ctx, oldProps.(map[string]interface{}), newProps.(map[string]interface{}))
-			}
+		CustomizeDiff: customdiff.All(resourceIndexMappingsDiff, resourceIndexSettingsRawDiff, resourceIndexStaticSettingsDiff),
 
-			// if all check passed, we can update the map
-			return false
-		}),
-
-		Schema: indexSchema,
+		Schema:        indexSchema,
+		SchemaVersion: 1,
+	}))
+	r.StateUpgraders = []schema.StateUpgrader{
+		{Version: 0, Type: r.CoreConfigSchema().ImpliedType(), Upgrade: resourceIndexStateUpgradeV0},
 	}
+	return r
 }
 
 func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -670,14 +697,46 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	var index models.Index
+
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := indexVersionRequirements.Check(d, serverVersion, serverFlavor); diags.HasError() {
+		return diags
+	}
+
+	index, diags := expandIndex(ctx, d)
+	if diags.HasError() {
+		return diags
+	}
 	index.Name = indexName
+	params, diags := expandPutIndexParams(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if diags := elasticsearch.PutIndex(ctx, client, index, params, indexErrorPaths...); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.String())
+	return resourceIndexRead(ctx, d, meta)
+}
+
+// expandIndex returns the aliases, mappings and settings of the configured index.
+func expandIndex(ctx context.Context, d *schema.ResourceData) (*models.Index, diag.Diagnostics) {
+	var index models.Index
 
 	if v, ok := d.GetOk("alias"); ok {
 		aliases := v.(*schema.Set)
 		als, diags := ExpandIndexAliases(aliases)
 		if diags.HasError() {
-			return diags
+			return nil, diags
 		}
 		index.Aliases = als
 	}
@@ -686,7 +745,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		maps := make(map[string]interface{})
 		if v.(string) != "" {
 			if err := json.Unmarshal([]byte(v.(string)), &maps); err != nil {
-				return diag.FromErr(err)
+				return nil, diag.FromErr(err)
 			}
 		}
 		index.Mappings = maps
@@ -703,7 +762,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		bytes := []byte(analyzerJSON.(string))
 		err := json.Unmarshal(bytes, &analyzer)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
 		analysis["analyzer"] = analyzer
 	}
@@ -712,7 +771,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		bytes := []byte(tokenizerJSON.(string))
 		err := json.Unmarshal(bytes, &tokenizer)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
 		analysis["tokenizer"] = tokenizer
 	}
@@ -720,7 +779,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		var filter map[string]interface{}
 		bytes := []byte(charFilterJSON.(string))
 		if err := json.Unmarshal(bytes, &filter); err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
 		analysis["char_filter"] = filter
 	}
@@ -729,7 +788,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		bytes := []byte(filterJSON.(string))
 		err := json.Unmarshal(bytes, &filter)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
 		analysis["filter"] = filter
 	}
@@ -738,7 +797,7 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		bytes := []byte(normalizerJSON.(string))
 		err := json.Unmarshal(bytes, &normalizer)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
 		analysis["normalizer"] = normalizer
 	}
@@ -753,44 +812,41 @@ func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta inter
 			setting := s.(map[string]interface{})
 			name := setting["name"].(string)
 			if _, ok := index.Settings[name]; ok {
-				return diag.FromErr(fmt.Errorf("setting '%s' is already defined by the other field, please remove it from `settings` to avoid unexpected settings", name))
+				return nil, diag.FromErr(fmt.Errorf("setting '%s' is already defined by the other field, please remove it from `settings` to avoid unexpected settings", name))
 			}
 			index.Settings[name] = setting["value"]
 		}
 	}
 
+	rawSettings, err := expandSettingsRaw(d.Get("settings_raw").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for name, value := range rawSettings {
+		index.Settings[name] = value
+	}
+
+	return &index, nil
+}
+
+func expandPutIndexParams(d *schema.ResourceData) (*models.PutIndexParams, diag.Diagnostics) {
 	params := models.PutIndexParams{
 		WaitForActiveShards: d.Get("wait_for_active_shards").(string),
 		IncludeTypeName:     d.Get("include_type_name").(bool),
 	}
-	serverVersion, diags := client.ServerVersion(ctx)
-	if diags.HasError() {
-		return diags
-	}
-	if includeTypeName := d.Get("include_type_name").(bool); includeTypeName {
-		if serverVersion.GreaterThanOrEqual(includeTypeNameMinUnsupportedVersion) {
-			return diag.FromErr(fmt.Errorf("'include_type_name' field is supported only for elasticsearch v7.x"))
-		}
-		params.IncludeTypeName = includeTypeName
-	}
 	masterTimeout, err := time.ParseDuration(d.Get("master_timeout").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, diag.FromErr(err)
 	}
 	params.MasterTimeout = masterTimeout
 
 	timeout, err := time.ParseDuration(d.Get("timeout").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, diag.FromErr(err)
 	}
 	params.Timeout = timeout
 
-	if diags := elasticsearch.PutIndex(ctx, client, &index, &params); diags.HasError() {
-		return diags
-	}
-
-	d.SetId(id.String())
-	return resourceIndexRead(ctx, d, meta)
+	return &params, nil
 }
 
 // Because of limitation of ES API we must handle changes to aliases, mappings and settings separately
@@ -799,7 +855,27 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	indexName := d.Get("name").(string)
+	indexName := backingIndex(d)
+
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := indexVersionRequirements.Check(d, serverVersion, serverFlavor); diags.HasError() {
+		return diags
+	}
+
+	// the new backing index is created with all the configured aliases, mappings and settings
+	if reindexPlanned(d) {
+		if diags := reindexIndex(ctx, client, d); diags.HasError() {
+			return diags
+		}
+		return resourceIndexRead(ctx, d, meta)
+	}
 
 	// aliases
 	if d.HasChange("alias") {
@@ -843,6 +919,28 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 			updatedSettings[key] = d.Get(fieldKey)
 		}
 	}
+	if d.HasChange("settings_raw") {
+		oldRaw, newRaw := d.GetChange("settings_raw")
+		os, err := expandSettingsRaw(oldRaw.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ns, err := expandSettingsRaw(newRaw.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// the settings removed from `settings_raw` are reset to their default value
+		for k := range os {
+			if _, ok := ns[k]; !ok {
+				updatedSettings[k] = nil
+			}
+		}
+		for k, v := range ns {
+			if ov, ok := os[k]; !ok || fmt.Sprint(ov) != fmt.Sprint(v) {
+				updatedSettings[k] = v
+			}
+		}
+	}
 	if d.HasChange("settings") {
 		oldSettings, newSettings := d.GetChange("settings")
 		os := flattenIndexSettings(oldSettings.([]interface{}))
@@ -867,9 +965,18 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 			}
 		}
 	}
+	if d.Get("allow_close_for_static_updates").(bool) {
+		staticSettings, diags := expandStaticSettingsChanges(d)
+		if diags.HasError() {
+			return diags
+		}
+		for k, v := range staticSettings {
+			updatedSettings[k] = v
+		}
+	}
 	if len(updatedSettings) > 0 {
 		tflog.Trace(ctx, fmt.Sprintf("settings to update: %+v", updatedSettings))
-		if diags := elasticsearch.UpdateIndexSettings(ctx, client, indexName, updatedSettings); diags.HasError() {
+		if diags := updateIndexSettings(ctx, client, d, indexName, updatedSettings); diags.HasError() {
 			return diags
 		}
 	}
@@ -878,7 +985,7 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	if d.HasChange("mappings") {
 		// at this point we know there are mappings defined and there is a change which we can apply
 		mappings := d.Get("mappings").(string)
-		if diags := elasticsearch.UpdateIndexMappings(ctx, client, indexName, mappings); diags.HasError() {
+		if diags := elasticsearch.UpdateIndexMappings(ctx, client, indexName, mappings, indexErrorPaths...); diags.HasError() {
 			return diags
 		}
 	}
@@ -886,6 +993,451 @@ func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	return resourceIndexRead(ctx, d, meta)
 }
 
+// resourceIndexMappingsDiff replaces the index when the mappings change in a way which can't be applied to the
+// existing index, unless the `reindex` strategy migrates the documents to a new backing index instead.
+func resourceIndexMappingsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("mappings") {
+		return nil
+	}
+	old, new := d.GetChange("mappings")
+	if !isMappingsChangeForceNewRequired(ctx, old.(string), new.(string)) {
+		return nil
+	}
+	if d.Get("mapping_change_strategy").(string) == "reindex" {
+		return d.SetNewComputed("backing_index")
+	}
+	return d.ForceNew("mappings")
+}
+
+func isMappingsChangeForceNewRequired(ctx context.Context, old, new string) bool {
+	o := make(map[string]interface{})
+	if err := json.NewDecoder(strings.NewReader(old)).Decode(&o); err != nil {
+		return true
+	}
+	n := make(map[string]interface{})
+	if err := json.NewDecoder(strings.NewReader(new)).Decode(&n); err != nil {
+		return true
+	}
+	tflog.Trace(ctx, "mappings custom diff old = %+v new = %+v", o, n)
+
+	// if old defined we must check if the type of the existing fields were changed
+	if oldProps, ok := o["properties"]; ok {
+		newProps, ok := n["properties"]
+		// if the old has props but new one not, immediately force new resource
+		if !ok {
+			return true
+		}
+		return IsMappingForceNewRequired(ctx, oldProps.(map[string]interface{}), newProps.(map[string]interface{}))
+	}
+
+	// if all check passed, we can update the map
+	return false
+}
+
+// resourceIndexSettingsRawDiff fails the plan when a setting of `settings_raw` is also defined by another attribute,
+// as one of the values would be silently discarded.
+func resourceIndexSettingsRawDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawSettings, err := expandSettingsRaw(d.Get("settings_raw").(string))
+	if err != nil || len(rawSettings) == 0 {
+		return err
+	}
+
+	defined := make(map[string]string)
+	for key := range allSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if _, ok := d.GetOk(fieldKey); ok {
+			defined[key] = fieldKey
+		}
+	}
+	for _, component := range analysisComponents {
+		if _, ok := d.GetOk("analysis_" + component); ok {
+			defined["analysis."+component] = "analysis_" + component
+		}
+	}
+	if v, ok := d.GetOk("settings"); ok {
+		for name := range flattenIndexSettings(v.([]interface{})) {
+			defined[strings.TrimPrefix(name, "index.")] = "settings"
+		}
+	}
+
+	names := make([]string, 0, len(rawSettings))
+	for name := range rawSettings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for key, attribute := range defined {
+			if name == key || strings.HasPrefix(name, key+".") || strings.HasPrefix(key, name+".") {
+				return fmt.Errorf("setting 'index.%s' is defined by both `settings_raw` and `%s`, please remove it from one of them", name, attribute)
+			}
+		}
+	}
+	return nil
+}
+
+// resourceIndexStaticSettingsDiff replaces the index when a static setting changes, unless
+// `allow_close_for_static_updates` lets the update close the index to apply it.
+func resourceIndexStaticSettingsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.Get("allow_close_for_static_updates").(bool) {
+		return nil
+	}
+	for key := range closableStaticSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if d.HasChange(fieldKey) {
+			if err := d.ForceNew(fieldKey); err != nil {
+				return err
+			}
+		}
+	}
+	// the analysis attributes aren't read from the index, an analysis added to an imported index isn't a change
+	for _, component := range analysisComponents {
+		fieldKey := "analysis_" + component
+		if old, _ := d.GetChange(fieldKey); old.(string) != "" && d.HasChange(fieldKey) {
+			return fmt.Errorf("`%s` can only be updated on a closed index, set `allow_close_for_static_updates` to close the index during the update", fieldKey)
+		}
+	}
+	return nil
+}
+
+// expandStaticSettingsChanges returns the changes of the static settings and of the analysis, with `nil` values
+// resetting the removed ones.
+func expandStaticSettingsChanges(d *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+	settings := make(map[string]interface{})
+	for key := range closableStaticSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		if d.HasChange(fieldKey) {
+			settings[key] = nil
+			if v, ok := d.GetOk(fieldKey); ok {
+				settings[key] = v
+			}
+		}
+	}
+	for _, component := range analysisComponents {
+		fieldKey := "analysis_" + component
+		if !d.HasChange(fieldKey) {
+			continue
+		}
+		oldAnalysis, newAnalysis := d.GetChange(fieldKey)
+		o, n := make(map[string]interface{}), make(map[string]interface{})
+		if oldAnalysis.(string) != "" {
+			if err := json.Unmarshal([]byte(oldAnalysis.(string)), &o); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+		if newAnalysis.(string) != "" {
+			if err := json.Unmarshal([]byte(newAnalysis.(string)), &n); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+		// the analysis settings are merged with the existing ones, the removed ones must be reset
+		o, n = utils.FlattenMap(o), utils.FlattenMap(n)
+		for k := range o {
+			if _, ok := n[k]; !ok {
+				settings["analysis."+component+"."+k] = nil
+			}
+		}
+		for k, v := range n {
+			settings["analysis."+component+"."+k] = v
+		}
+	}
+	return settings, nil
+}
+
+// updateIndexSettings updates the settings of the index. When some of them are static and
+// `allow_close_for_static_updates` is set, the index is closed during the update, then reopened and the update waits
+// for its primary shards to be allocated again.
+func updateIndexSettings(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData, indexName string, settings map[string]interface{}) diag.Diagnostics {
+	var static []string
+	for key := range settings {
+		if isStaticIndexSetting(key) {
+			static = append(static, "index."+key)
+		}
+	}
+	if len(static) == 0 || !d.Get("allow_close_for_static_updates").(bool) {
+		return elasticsearch.UpdateIndexSettings(ctx, client, indexName, settings, indexErrorPaths...)
+	}
+
+	sort.Strings(static)
+	tflog.Info(ctx, fmt.Sprintf("Closing index '%s' to update its static settings %v", indexName, static))
+	if diags := elasticsearch.CloseIndex(ctx, client, indexName); diags.HasError() {
+		return diags
+	}
+	diags := elasticsearch.UpdateIndexSettings(ctx, client, indexName, settings, indexErrorPaths...)
+
+	// the index is reopened even if the update failed, or the update timed out, not to leave it unavailable
+	openCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if openDiags := elasticsearch.OpenIndex(openCtx, client, indexName); openDiags.HasError() {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Index %s was left closed", indexName),
+			Detail:   fmt.Sprintf("The index was closed to update its static settings %v but couldn't be reopened, it can be reopened with the open index API: %s", static, openDiags[0].Summary),
+		})
+	}
+	if diags.HasError() {
+		return diags
+	}
+	return elasticsearch.WaitForIndexHealth(ctx, client, indexName)
+}
+
+// isStaticIndexSetting returns whether the setting can only be updated on a closed index.
+func isStaticIndexSetting(name string) bool {
+	name = strings.TrimPrefix(name, "index.")
+	if _, ok := staticSettingsKeys[name]; ok {
+		return true
+	}
+	return strings.HasPrefix(name, "analysis.") || strings.HasPrefix(name, "similarity.")
+}
+
+// expandSettingsRaw returns the flattened settings of `settings_raw`, named without the `index.` prefix like the
+// settings defined by the other attributes.
+func expandSettingsRaw(raw string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	if raw == "" {
+		return settings, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		return nil, err
+	}
+	for key, value := range utils.FlattenMap(m) {
+		settings[strings.TrimPrefix(key, "index.")] = value
+	}
+	return settings, nil
+}
+
+// flattenIndexSettingsAttributes returns the flat settings of an index which have an attribute, keyed by the attribute
+// and decoded to its type, and the other ones as the `settings_raw` JSON, without the settings set by Elasticsearch.
+func flattenIndexSettingsAttributes(indexSettings map[string]interface{}) (map[string]interface{}, string, error) {
+	settings := make(map[string]interface{})
+	for key, typ := range allSettingsKeys {
+		var value interface{}
+		if v, ok := indexSettings[key]; ok {
+			value = v
+		} else if v, ok := indexSettings["index."+key]; ok {
+			value = v
+		} else {
+			continue
+		}
+		switch typ {
+		case schema.TypeInt:
+			v, err := strconv.Atoi(value.(string))
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to convert setting '%s' value %v to int: %w", key, value, err)
+			}
+			value = v
+		case schema.TypeBool:
+			v, err := strconv.ParseBool(value.(string))
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to convert setting '%s' value %v to bool: %w", key, value, err)
+			}
+			value = v
+		case schema.TypeSet, schema.TypeList:
+			// a single value may be returned as a string
+			if v, ok := value.(string); ok {
+				value = []interface{}{v}
+			}
+		}
+		settings[utils.ConvertSettingsKeyToTFFieldKey(key)] = value
+	}
+
+	rawSettings := make(map[string]interface{})
+	for key, value := range indexSettings {
+		name := strings.TrimPrefix(key, "index.")
+		if _, ok := allSettingsKeys[name]; ok || isInternalIndexSetting(name) {
+			continue
+		}
+		rawSettings[key] = value
+	}
+	if len(rawSettings) == 0 {
+		return settings, "", nil
+	}
+	s, err := json.Marshal(rawSettings)
+	if err != nil {
+		return nil, "", err
+	}
+	return settings, string(s), nil
+}
+
+// isInternalIndexSetting returns whether the setting is set by Elasticsearch when the index is created. Such settings
+// either can't be defined in the configuration, or would be removed from the index when not configured, e.g. the
+// tier preference added to all the indices from Elasticsearch 7.10.
+func isInternalIndexSetting(name string) bool {
+	switch name {
+	case "uuid", "creation_date", "provided_name", "verified_before_close", "routing.allocation.include._tier_preference":
+		return true
+	}
+	return strings.HasPrefix(name, "version.") || strings.HasPrefix(name, "resize.") ||
+		strings.HasPrefix(name, "routing.allocation.initial_recovery.")
+}
+
+// resourceIndexStateUpgradeV0 drops the `settings_raw` of the states written when it held all the settings of the
+// index, which would otherwise be tracked as configured settings.
+func resourceIndexStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	delete(rawState, "settings_raw")
+	return rawState, nil
+}
+
+// backingIndex returns the name of the index storing the documents, which is the name of the resource until the
+// mappings are migrated to a new backing index.
+func backingIndex(d *schema.ResourceData) string {
+	if index, _ := d.GetChange("backing_index"); index.(string) != "" {
+		return index.(string)
+	}
+	return d.Get("name").(string)
+}
+
+// reindexPlanned returns whether the plan migrates the documents to a new backing index, whose name is only known
+// once it is created.
+func reindexPlanned(d *schema.ResourceData) bool {
+	plan := d.GetRawPlan()
+	return !plan.IsNull() && plan.IsKnown() && !plan.GetAttr("backing_index").IsKnown()
+}
+
+// nextBackingIndex returns the name of the backing index following the current one: `<name>-000001`, then
+// `<name>-000002`, and so on.
+func nextBackingIndex(name, current string) string {
+	var generation int
+	if suffix, ok := strings.CutPrefix(current, name+"-"); ok {
+		generation, _ = strconv.Atoi(suffix)
+	}
+	return fmt.Sprintf("%s-%06d", name, generation+1)
+}
+
+// removeWriteBlock removes the write block from the settings, whether it's configured with the `blocks_write`
+// attribute or as `index.blocks.write` in the `settings` block, and returns whether writes are blocked.
+func removeWriteBlock(settings map[string]interface{}) bool {
+	var blockWrites bool
+	for key, value := range settings {
+		if strings.TrimPrefix(key, "index.") != "blocks.write" {
+			continue
+		}
+		switch v := value.(type) {
+		case bool:
+			blockWrites = v
+		case string:
+			blockWrites, _ = strconv.ParseBool(v)
+		}
+		delete(settings, key)
+	}
+	return blockWrites
+}
+
+// writesBlocked returns whether the previous configuration blocked the writes to the index, with the `blocks_write`
+// attribute, in the `settings` block or in `settings_raw`.
+func writesBlocked(d *schema.ResourceData) bool {
+	if blocked, _ := d.GetChange("blocks_write"); blocked.(bool) {
+		return true
+	}
+	oldSettings, _ := d.GetChange("settings")
+	settings := flattenIndexSettings(oldSettings.([]interface{}))
+	oldRaw, _ := d.GetChange("settings_raw")
+	if rawSettings, err := expandSettingsRaw(oldRaw.(string)); err == nil {
+		for name, value := range rawSettings {
+			settings[name] = value
+		}
+	}
+	return removeWriteBlock(settings)
+}
+
+// reindexIndex migrates the documents of the backing index to a new backing index created with the configured
+// mappings and settings. Writes to the previous index are blocked during the reindex, then the previous index is
+// deleted and its aliases moved to the new index in a single atomic request, the name of the resource becoming an
+// alias of the new index. If the migration fails, the new index is deleted and the previous one unblocked, unless the
+// configuration blocked its writes. A new index which can't be deleted is reported as an error, as it would fail the
+// next migration.
+func reindexIndex(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData) diag.Diagnostics {
+	name := d.Get("name").(string)
+	oldIndex := backingIndex(d)
+	newIndex := nextBackingIndex(name, oldIndex)
+
+	index, diags := expandIndex(ctx, d)
+	if diags.HasError() {
+		return diags
+	}
+	// the aliases are moved once the documents are copied
+	aliases := index.Aliases
+	index.Aliases = nil
+	// a write block would fail the reindex, it's applied once the documents are copied
+	blockWrites := removeWriteBlock(index.Settings)
+	index.Name = newIndex
+	params, diags := expandPutIndexParams(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Migrating the documents of index '%s' to the new backing index '%s'", oldIndex, newIndex))
+	if diags := elasticsearch.PutIndex(ctx, client, index, params, indexErrorPaths...); diags.HasError() {
+		return diags
+	}
+
+	var blocked bool
+	var taskID string
+	rollback := func(diags diag.Diagnostics) diag.Diagnostics {
+		// the context may have expired with the timeout of the update, leaving the reindex task running
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		var cleanups []diag.Diagnostics
+		if taskID != "" && ctx.Err() != nil {
+			cleanups = append(cleanups, elasticsearch.CancelTask(cleanupCtx, client, taskID))
+		}
+		if cleanup := elasticsearch.DeleteIndex(cleanupCtx, client, newIndex); cleanup.HasError() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to delete the new backing index '%s'", newIndex),
+				Detail:   fmt.Sprintf("The index is left over by the failed reindex, and must be deleted before applying the configuration again: %s", cleanup[0].Summary),
+			})
+		}
+		if blocked {
+			cleanups = append(cleanups, elasticsearch.UpdateIndexSettings(cleanupCtx, client, oldIndex, map[string]interface{}{"index.blocks.write": nil}))
+		}
+		for _, cleanup := range cleanups {
+			for _, warning := range cleanup {
+				warning.Severity = diag.Warning
+				warning.Summary = "Unable to roll back the reindex: " + warning.Summary
+				diags = append(diags, warning)
+			}
+		}
+		return diags
+	}
+
+	// documents written to the previous index during the reindex would be lost
+	if !writesBlocked(d) {
+		if diags := elasticsearch.UpdateIndexSettings(ctx, client, oldIndex, map[string]interface{}{"index.blocks.write": true}); diags.HasError() {
+			return rollback(diags)
+		}
+		blocked = true
+	}
+	taskID, diags = elasticsearch.Reindex(ctx, client, oldIndex, newIndex)
+	if diags.HasError() {
+		return rollback(diags)
+	}
+	if diags := elasticsearch.WaitForTask(ctx, client, taskID); diags.HasError() {
+		return rollback(diags)
+	}
+	if blockWrites {
+		if diags := elasticsearch.UpdateIndexSettings(ctx, client, newIndex, map[string]interface{}{"index.blocks.write": true}); diags.HasError() {
+			return rollback(diags)
+		}
+	}
+
+	actions := []models.AliasAction{
+		{RemoveIndex: &models.AliasActionParams{Index: oldIndex}},
+		{Add: &models.AliasActionParams{Index: newIndex, Alias: name}},
+	}
+	for aliasName, alias := range aliases {
+		actions = append(actions, models.AliasAction{Add: &models.AliasActionParams{Index: newIndex, Alias: aliasName, IndexAlias: alias}})
+	}
+	if diags := elasticsearch.UpdateAliases(ctx, client, actions); diags.HasError() {
+		return rollback(diags)
+	}
+
+	if err := d.Set("backing_index", newIndex); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
 func flattenIndexSettings(settings []interface{}) map[string]interface{} {
 	ns := make(map[string]interface{})
 	if len(settings) > 0 {
@@ -924,6 +1476,12 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diags
 	}
 
+	if err := d.Set("backing_index", index.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	// the name of the resource is an alias of the backing index, managed by the resource
+	delete(index.Aliases, indexName)
+
 	if index.Aliases != nil {
 		aliases, diags := FlattenIndexAliases(index.Aliases)
 		if diags.HasError() {
@@ -944,8 +1502,19 @@ func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	// TODO: We ideally should set read settings to each field to detect changes
 	// But for now, setting it will cause unexpected diff for the existing clients which use `settings`
-	if index.Settings != nil {
-		s, err := json.Marshal(index.Settings)
+	// only the settings defined in `settings_raw` are tracked, the other ones being defaults or managed by Elasticsearch
+	if v, ok := d.GetOk("settings_raw"); ok {
+		tracked, err := expandSettingsRaw(v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rawSettings := make(map[string]interface{})
+		for name := range tracked {
+			if value, ok := index.Settings["index."+name]; ok {
+				rawSettings["index."+name] = value
+			}
+		}
+		s, err := json.Marshal(rawSettings)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -964,12 +1533,7 @@ func resourceIndexDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	if diags.HasError() {
 		return diags
 	}
-	id := d.Id()
-	compId, diags := clients.CompositeIdFromStr(id)
-	if diags.HasError() {
-		return diags
-	}
-	if diags := elasticsearch.DeleteIndex(ctx, client, compId.ResourceId); diags.HasError() {
+	if diags := elasticsearch.DeleteIndex(ctx, client, backingIndex(d)); diags.HasError() {
 		return diags
 	}
 	return diags
@@ -3,18 +3,30 @@ package index_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/go-cty/cty"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccResourceIndex(t *testing.T) {
+	cassette.Start(t)
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -53,6 +65,7 @@ func TestAccResourceIndex(t *testing.T) {
 }
 
 func TestAccResourceIndexSettings(t *testing.T) {
+	cassette.Start(t)
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -104,6 +117,7 @@ func TestAccResourceIndexSettings(t *testing.T) {
 }
 
 func TestAccResourceIndexSettingsMigration(t *testing.T) {
+	cassette.Start(t)
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -133,6 +147,7 @@ func TestAccResourceIndexSettingsMigration(t *testing.T) {
 }
 
 func TestAccResourceIndexSettingsConflict(t *testing.T) {
+	cassette.Start(t)
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -149,6 +164,7 @@ func TestAccResourceIndexSettingsConflict(t *testing.T) {
 }
 
 func TestAccResourceIndexRemovingField(t *testing.T) {
+	cassette.Start(t)
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -441,6 +457,195 @@ resource "elasticstack_elasticsearch_index" "test_settings_removing_field" {
 	`, name)
 }
 
+func TestAccResourceIndexMappingChangeReindex(t *testing.T) {
+	cassette.Start(t)
+	indexName := strings.ToLower(sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexMappingChangeReindex(indexName, "keyword"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "backing_index", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "alias.#", "1"),
+				),
+			},
+			{
+				Config: testAccResourceIndexMappingChangeReindex(indexName, "text"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_index.test_reindex", "id", func(value string) error {
+						if !strings.HasSuffix(value, "/"+indexName) {
+							return fmt.Errorf("expected the index to keep the ID of %s, got %s", indexName, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "name", indexName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "backing_index", indexName+"-000001"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "mappings", `{"properties":{"field1":{"type":"text"}}}`),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "alias.#", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "alias.0.name", indexName+"_alias"),
+					resource.TestCheckTypeSetElemNestedAttrs("elasticstack_elasticsearch_index.test_reindex", "settings.0.setting.*", map[string]string{
+						"name":  "index.blocks.write",
+						"value": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccResourceIndexMappingChangeReindex(indexName, "keyword"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_reindex", "backing_index", indexName+"-000002"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceIndexMappingsDiff(t *testing.T) {
+	tests := []struct {
+		name                string
+		strategy            string
+		fieldType           string
+		wantRequiresNew     bool
+		wantNewBackingIndex bool
+	}{
+		{name: "field added", strategy: "recreate"},
+		{name: "field type changed", strategy: "recreate", fieldType: "text", wantRequiresNew: true},
+		{name: "field added with the reindex strategy", strategy: "reindex"},
+		{name: "field type changed with the reindex strategy", strategy: "reindex", fieldType: "text", wantNewBackingIndex: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mappings := `{"properties":{"field1":{"type":"keyword"},"field2":{"type":"keyword"}}}`
+			if tt.fieldType != "" {
+				mappings = fmt.Sprintf(`{"properties":{"field1":{"type":"%s"}}}`, tt.fieldType)
+			}
+			state := &terraform.InstanceState{ID: "cluster-uuid/my-index", Attributes: map[string]string{
+				"id":                      "cluster-uuid/my-index",
+				"name":                    "my-index",
+				"backing_index":           "my-index",
+				"mappings":                `{"properties":{"field1":{"type":"keyword"}}}`,
+				"mapping_change_strategy": tt.strategy,
+				"deletion_protection":     "true",
+				"include_type_name":       "false",
+				"wait_for_active_shards":  "1",
+				"master_timeout":          "30s",
+				"timeout":                 "30s",
+			}}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":                    "my-index",
+				"mappings":                mappings,
+				"mapping_change_strategy": tt.strategy,
+			})
+
+			diff, err := index.ResourceIndex().SimpleDiff(context.Background(), state, config, nil)
+			require.NoError(t, err)
+			require.NotNil(t, diff)
+			require.Equal(t, tt.wantRequiresNew, diff.RequiresNew())
+			backingIndex, ok := diff.Attributes["backing_index"]
+			require.Equal(t, tt.wantNewBackingIndex, ok && backingIndex.NewComputed)
+		})
+	}
+}
+
+func TestResourceIndexReindexRollback(t *testing.T) {
+	server := fake.NewServer(t)
+	server.Setenv(t)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the reindex fails, and so does the deletion of the new backing index when rolling back
+		if r.URL.Path == "/_reindex" || (r.Method == http.MethodDelete && r.URL.Path == "/my-index-000001") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"type":"exception","reason":"failure"},"status":500}`))
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	defer failing.Close()
+	t.Setenv("ELASTICSEARCH_ENDPOINTS", failing.URL)
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.False(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: "my-index", Settings: map[string]interface{}{
+		"blocks.write": true,
+	}}, &models.PutIndexParams{}).HasError())
+
+	r := index.ResourceIndex()
+	state := &terraform.InstanceState{ID: "fake-cluster-uuid/my-index", Attributes: map[string]string{
+		"id":                      "fake-cluster-uuid/my-index",
+		"name":                    "my-index",
+		"backing_index":           "my-index",
+		"mappings":                `{"properties":{"field1":{"type":"keyword"}}}`,
+		"mapping_change_strategy": "reindex",
+		"settings_raw":            `{"index.blocks.write":"true"}`,
+		"deletion_protection":     "true",
+		"include_type_name":       "false",
+		"wait_for_active_shards":  "1",
+		"master_timeout":          "30s",
+		"timeout":                 "30s",
+	}}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                    "my-index",
+		"mappings":                `{"properties":{"field1":{"type":"text"}}}`,
+		"mapping_change_strategy": "reindex",
+		"settings_raw":            `{"index.blocks.write":"true"}`,
+	})
+	diff, err := r.SimpleDiff(ctx, state, config, nil)
+	require.NoError(t, err)
+	diff.RawPlan = cty.ObjectVal(map[string]cty.Value{"backing_index": cty.UnknownVal(cty.String)})
+
+	_, diags := r.Apply(ctx, state, diff, client)
+	require.True(t, diags.HasError())
+	var summaries []string
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary)
+	}
+	require.Contains(t, summaries, "Unable to delete the new backing index 'my-index-000001'")
+
+	previous, diags := elasticsearch.GetIndex(ctx, client, "my-index")
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, "true", previous.Settings["index.blocks.write"], "the write block of the configuration is kept")
+}
+
+func testAccResourceIndexMappingChangeReindex(name, fieldType string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_reindex" {
+  name = "%[1]s"
+
+  alias {
+    name = "%[1]s_alias"
+  }
+
+  mappings = jsonencode({
+    properties = {
+      field1 = { type = "%[2]s" }
+    }
+  })
+
+  # the write block is applied to the new backing index once the documents are copied
+  settings {
+    setting {
+      name  = "index.blocks.write"
+      value = "true"
+    }
+  }
+
+  mapping_change_strategy = "reindex"
+  deletion_protection     = false
+}
+	`, name, fieldType)
+}
+
 func checkResourceIndexDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
@@ -469,6 +674,234 @@ func checkResourceIndexDestroy(s *terraform.State) error {
 	return nil
 }
 
+func TestAccResourceIndexSettingsRaw(t *testing.T) {
+	cassette.Start(t)
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexSettingsRaw(indexName, `{ index = { mapping = { total_fields = { limit = 2000 } } }, "index.mapping.depth.limit" = 30 }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_raw", "number_of_replicas", "1"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_raw", "settings_raw", `{"index.mapping.depth.limit":"30","index.mapping.total_fields.limit":"2000"}`),
+				),
+			},
+			{
+				Config: testAccResourceIndexSettingsRaw(indexName, `{ "mapping.total_fields.limit" = 3000 }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_settings_raw", "settings_raw", `{"index.mapping.total_fields.limit":"3000"}`),
+				),
+			},
+			{
+				Config:      testAccResourceIndexSettingsRaw(indexName, `{ "index.number_of_replicas" = 2 }`),
+				ExpectError: regexp.MustCompile("setting 'index.number_of_replicas' is defined by both `settings_raw` and `number_of_replicas`"),
+			},
+		},
+	})
+}
+
+func TestResourceIndexSettingsRawDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]interface{}
+		wantError string
+	}{
+		{
+			name:   "settings without an attribute",
+			config: map[string]interface{}{"number_of_replicas": 1, "settings_raw": `{"index":{"mode":"time_series","routing_path":["host"]}}`},
+		},
+		{
+			name:      "setting defined by an attribute",
+			config:    map[string]interface{}{"number_of_replicas": 1, "settings_raw": `{"number_of_replicas":2}`},
+			wantError: "setting 'index.number_of_replicas' is defined by both `settings_raw` and `number_of_replicas`",
+		},
+		{
+			name:      "analysis defined by an attribute",
+			config:    map[string]interface{}{"analysis_analyzer": `{"my_analyzer":{"type":"standard"}}`, "settings_raw": `{"index.analysis.analyzer.other.type":"simple"}`},
+			wantError: "setting 'index.analysis.analyzer.other.type' is defined by both `settings_raw` and `analysis_analyzer`",
+		},
+		{
+			name: "setting defined by the settings block",
+			config: map[string]interface{}{
+				"settings":     []interface{}{map[string]interface{}{"setting": []interface{}{map[string]interface{}{"name": "index.refresh_interval", "value": "10s"}}}},
+				"settings_raw": `{"refresh_interval":"5s"}`,
+			},
+			wantError: "setting 'index.refresh_interval' is defined by both `settings_raw` and `settings`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["name"] = "my-index"
+			_, err := index.ResourceIndex().SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), nil)
+			if tt.wantError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantError)
+		})
+	}
+}
+
+func TestResourceIndexImportSettingsRaw(t *testing.T) {
+	server := fake.NewServer(t)
+	server.Setenv(t)
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.False(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: "my-index", Settings: map[string]interface{}{
+		"number_of_replicas":         2,
+		"mapping.total_fields.limit": 2000,
+	}}, &models.PutIndexParams{}).HasError())
+
+	r := index.ResourceIndex()
+	d := r.TestResourceData()
+	d.SetId("fake-cluster-uuid/my-index")
+	imported, err := r.Importer.StateContext(ctx, d, client)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, 2, imported[0].Get("number_of_replicas"))
+	require.JSONEq(t, `{"index.mapping.total_fields.limit":"2000"}`, imported[0].Get("settings_raw").(string))
+}
+
+func TestResourceIndexStateUpgradeV0(t *testing.T) {
+	r := index.ResourceIndex()
+	require.Equal(t, 1, r.SchemaVersion)
+
+	state, err := r.StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+		"id":           "cluster-uuid/my-index",
+		"name":         "my-index",
+		"settings_raw": `{"index.creation_date":"1690000000000","index.number_of_shards":"1","index.uuid":"abc"}`,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"id": "cluster-uuid/my-index", "name": "my-index"}, state)
+}
+
+func TestAccResourceIndexStaticSettingsUpdate(t *testing.T) {
+	cassette.Start(t)
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceIndexDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIndexStaticSettingsUpdate(indexName, "default", "standard"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_static_settings", "codec", ""),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_static_settings", "analysis_analyzer", `{"text_en":{"tokenizer":"standard","type":"custom"}}`),
+				),
+			},
+			{
+				Config: testAccResourceIndexStaticSettingsUpdate(indexName, "best_compression", "whitespace"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_static_settings", "codec", "best_compression"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index.test_static_settings", "analysis_analyzer", `{"text_en":{"tokenizer":"whitespace","type":"custom"}}`),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceIndexStaticSettingsDiff(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowClose      bool
+		oldAnalyzer     string
+		codec           string
+		analyzer        string
+		wantRequiresNew bool
+		wantError       string
+	}{
+		{name: "codec changed", codec: "best_compression", wantRequiresNew: true},
+		{name: "codec changed on a closed index", allowClose: true, codec: "best_compression"},
+		{
+			name:        "analyzer changed",
+			oldAnalyzer: `{"text_en":{"type":"standard"}}`,
+			analyzer:    `{"text_en":{"type":"simple"}}`,
+			wantError:   "`analysis_analyzer` can only be updated on a closed index",
+		},
+		{name: "analyzer changed on a closed index", allowClose: true, oldAnalyzer: `{"text_en":{"type":"standard"}}`, analyzer: `{"text_en":{"type":"simple"}}`},
+		{name: "analyzer defined on an imported index", analyzer: `{"text_en":{"type":"simple"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: "cluster-uuid/my-index", Attributes: map[string]string{
+				"id":                             "cluster-uuid/my-index",
+				"name":                           "my-index",
+				"backing_index":                  "my-index",
+				"mappings":                       "{}",
+				"analysis_analyzer":              tt.oldAnalyzer,
+				"allow_close_for_static_updates": fmt.Sprint(tt.allowClose),
+				"mapping_change_strategy":        "recreate",
+				"deletion_protection":            "true",
+				"include_type_name":              "false",
+				"wait_for_active_shards":         "1",
+				"master_timeout":                 "30s",
+				"timeout":                        "30s",
+			}}
+			config := map[string]interface{}{"name": "my-index", "allow_close_for_static_updates": tt.allowClose}
+			if tt.codec != "" {
+				config["codec"] = tt.codec
+			}
+			if tt.analyzer != "" {
+				config["analysis_analyzer"] = tt.analyzer
+			}
+
+			diff, err := index.ResourceIndex().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+			if tt.wantError != "" {
+				require.ErrorContains(t, err, tt.wantError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, diff)
+			require.Equal(t, tt.wantRequiresNew, diff.RequiresNew())
+		})
+	}
+}
+
+func testAccResourceIndexStaticSettingsUpdate(name, codec, tokenizer string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_static_settings" {
+  name                           = "%s"
+  codec                          = %q == "default" ? null : %q
+  allow_close_for_static_updates = true
+  deletion_protection            = false
+
+  analysis_analyzer = jsonencode({
+    text_en = {
+      type      = "custom"
+      tokenizer = "%s"
+    }
+  })
+}
+	`, name, codec, codec, tokenizer)
+}
+
+func testAccResourceIndexSettingsRaw(name, settings string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test_settings_raw" {
+  name                = "%s"
+  number_of_replicas  = 1
+  settings_raw        = jsonencode(%s)
+  deletion_protection = false
+}
+	`, name, settings)
+}
+
 func Test_IsMappingForceNewRequired(t *testing.T) {
 	t.Parallel()
 
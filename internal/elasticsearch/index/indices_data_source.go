@@ -0,0 +1,211 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceIndices() *schema.Resource {
+	indexSchema := map[string]*schema.Schema{
+		"name": {
+			Description: "Name of the index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"uuid": {
+			Description: "Universally unique identifier of the index.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"health": {
+			Description: "Health of the index: `green`, `yellow` or `red`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"status": {
+			Description: "Whether the index is `open` or `close`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"docs_count": {
+			Description: "Number of documents in the index, including the nested documents. `0` for closed indices, whose statistics aren't available.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"store_size_in_bytes": {
+			Description: "Size of the index on disk, including its replicas. `0` for closed indices, whose statistics aren't available.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"mappings": {
+			Description: "Mappings of the index, as a JSON object.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"settings_raw": {
+			Description: "Settings of the index which aren't available as attributes, as a JSON object.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+	// the settings and aliases are described like the ones of the index resource
+	resourceSchema := ResourceIndex().Schema
+	for key := range allSettingsKeys {
+		fieldKey := utils.ConvertSettingsKeyToTFFieldKey(key)
+		indexSchema[fieldKey] = computedSchema(resourceSchema[fieldKey])
+	}
+	indexSchema["alias"] = computedSchema(resourceSchema["alias"])
+
+	indicesSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"search": {
+			Description: "Comma-separated list of indices, aliases and wildcard patterns, such as `logs-*`, matching the indices to return. Defaults to all the indices.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "*",
+		},
+		"expand_wildcards": {
+			Description: "Comma-separated list of the kinds of indices the wildcard patterns can match: `all`, `open`, `closed`, `hidden` or `none`. Defaults to `open`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "open",
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^(all|open|closed|hidden|none)(,(all|open|closed|hidden|none))*$`),
+				"must be a comma-separated list of `all`, `open`, `closed`, `hidden` or `none`",
+			),
+		},
+		"indices": {
+			Description: "Indices matching the search, sorted by name.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Resource{Schema: indexSchema},
+		},
+	}
+
+	utils.AddConnectionSchema(indicesSchema)
+
+	return &schema.Resource{
+		Description: "Retrieves the indices matching a pattern, with their settings, mappings, aliases and statistics. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-get-index.html",
+		ReadContext: dataSourceIndicesRead,
+		Schema:      indicesSchema,
+	}
+}
+
+// computedSchema returns a copy of the schema of a resource attribute, read-only in a data source.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Type:        s.Type,
+		Description: s.Description,
+		Computed:    true,
+		Elem:        s.Elem,
+	}
+	if elem, ok := s.Elem.(*schema.Resource); ok {
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for key, nestedSchema := range elem.Schema {
+			nested[key] = computedSchema(nestedSchema)
+		}
+		computed.Elem = &schema.Resource{Schema: nested}
+	}
+	return computed
+}
+
+func dataSourceIndicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	search := d.Get("search").(string)
+	expandWildcards := d.Get("expand_wildcards").(string)
+	id, diags := client.ID(ctx, search)
+	if diags.HasError() {
+		return diags
+	}
+
+	indices, diags := elasticsearch.GetIndices(ctx, client, search, expandWildcards)
+	if diags.HasError() {
+		return diags
+	}
+	catIndices, diags := elasticsearch.CatIndices(ctx, client, search, expandWildcards)
+	if diags.HasError() {
+		return diags
+	}
+	stats := make(map[string]models.CatIndex, len(catIndices))
+	for _, catIndex := range catIndices {
+		stats[catIndex.Index] = catIndex
+	}
+
+	names := make([]string, 0, len(indices))
+	for name := range indices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		index, diags := flattenIndex(indices[name], stats[name])
+		if diags.HasError() {
+			return diags
+		}
+		result = append(result, index)
+	}
+
+	d.SetId(id.String())
+	if err := d.Set("indices", result); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func flattenIndex(index models.Index, stats models.CatIndex) (map[string]interface{}, diag.Diagnostics) {
+	settings, rawSettings, err := flattenIndexSettingsAttributes(index.Settings)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	result := settings
+	result["name"] = index.Name
+	result["settings_raw"] = rawSettings
+	result["uuid"] = stats.UUID
+	result["health"] = stats.Health
+	result["status"] = stats.Status
+	if stats.DocsCount != "" {
+		docsCount, err := strconv.Atoi(stats.DocsCount)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("failed to convert the document count %s of index %s: %w", stats.DocsCount, index.Name, err))
+		}
+		result["docs_count"] = docsCount
+	}
+	if stats.StoreSize != "" {
+		storeSize, err := strconv.Atoi(stats.StoreSize)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("failed to convert the store size %s of index %s: %w", stats.StoreSize, index.Name, err))
+		}
+		result["store_size_in_bytes"] = storeSize
+	}
+
+	mappings, err := json.Marshal(index.Mappings)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	result["mappings"] = string(mappings)
+	aliases, diags := FlattenIndexAliases(index.Aliases)
+	if diags.HasError() {
+		return nil, diags
+	}
+	result["alias"] = aliases
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package index_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDataSourceIndices(t *testing.T) {
+	cassette.Start(t)
+	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIndices(indexName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.#", "2"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.name", indexName+"-1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.number_of_replicas", "0"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.status", "open"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.docs_count", "0"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.mappings", `{"properties":{"field1":{"type":"keyword"}}}`),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.0.alias.#", "1"),
+					resource.TestCheckResourceAttr("data.elasticstack_elasticsearch_indices.test", "indices.1.name", indexName+"-2"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceIndicesRead(t *testing.T) {
+	server := fake.NewServer(t)
+	server.Setenv(t)
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, name := range []string{"logs-2", "logs-1", "metrics-1"} {
+		require.False(t, elasticsearch.PutIndex(ctx, client, &models.Index{Name: name, Settings: map[string]interface{}{
+			"number_of_replicas":         0,
+			"sort.field":                 []interface{}{"@timestamp"},
+			"mapping.total_fields.limit": 2000,
+		}}, &models.PutIndexParams{}).HasError())
+	}
+	require.False(t, elasticsearch.CloseIndex(ctx, client, "logs-2").HasError())
+
+	tests := []struct {
+		name            string
+		search          string
+		expandWildcards string
+		want            []string
+	}{
+		{name: "wildcard", search: "logs-*", want: []string{"logs-1"}},
+		{name: "wildcard with closed indices", search: "logs-*", expandWildcards: "open,closed", want: []string{"logs-1", "logs-2"}},
+		{name: "several patterns", search: "logs-1,metrics-*", want: []string{"logs-1", "metrics-1"}},
+		{name: "no match", search: "traces-*", want: []string{}},
+		{name: "existing and missing indices", search: "logs-1,traces", want: []string{"logs-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := index.DataSourceIndices()
+			d := r.TestResourceData()
+			require.NoError(t, d.Set("search", tt.search))
+			if tt.expandWildcards != "" {
+				require.NoError(t, d.Set("expand_wildcards", tt.expandWildcards))
+			}
+			diags := r.ReadContext(ctx, d, client)
+			require.False(t, diags.HasError(), diags)
+
+			names := []string{}
+			for _, i := range d.Get("indices").([]interface{}) {
+				index := i.(map[string]interface{})
+				names = append(names, index["name"].(string))
+				require.NotEmpty(t, index["health"], "the statistics of the index are returned")
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+
+	r := index.DataSourceIndices()
+	d := r.TestResourceData()
+	require.NoError(t, d.Set("search", "logs-1"))
+	require.False(t, r.ReadContext(ctx, d, client).HasError())
+	require.Equal(t, 0, d.Get("indices.0.number_of_replicas"))
+	require.Equal(t, []interface{}{"@timestamp"}, d.Get("indices.0.sort_field").(interface{ List() []interface{} }).List())
+	require.Equal(t, `{"index.mapping.total_fields.limit":"2000"}`, d.Get("indices.0.settings_raw"))
+	require.Equal(t, "green", d.Get("indices.0.health"))
+	require.Equal(t, 225, d.Get("indices.0.store_size_in_bytes"))
+	require.NotEmpty(t, d.Get("indices.0.uuid"))
+}
+
+func testAccDataSourceIndices(name string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_index" "test" {
+  count               = 2
+  name                = "%s-${count.index + 1}"
+  number_of_replicas  = 0
+  deletion_protection = false
+
+  alias {
+    name = "%s-alias-${count.index + 1}"
+  }
+
+  mappings = jsonencode({
+    properties = {
+      field1 = { type = "keyword" }
+    }
+  })
+}
+
+data "elasticstack_elasticsearch_indices" "test" {
+  search = "%s-*"
+
+  depends_on = [elasticstack_elasticsearch_index.test]
+}
+	`, name, name, name)
+}
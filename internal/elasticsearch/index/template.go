@@ -165,7 +165,7 @@ func ResourceTemplate() *schema.Resource {
 
 	utils.AddConnectionSchema(templateSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Creates or updates an index template. Index templates define settings, mappings, and aliases that can be applied automatically to new indices. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-put-template.html",
 
 		CreateContext: resourceIndexTemplatePut,
@@ -178,7 +178,7 @@ func ResourceTemplate() *schema.Resource {
 		},
 
 		Schema: templateSchema,
-	}
+	})
 }
 
 func resourceIndexTemplatePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
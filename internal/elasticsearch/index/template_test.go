@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -13,38 +15,54 @@ import (
 
 func TestAccResourceIndexTemplate(t *testing.T) {
 	// generate random template name
+	cassette.Start(t)
 	templateName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
 		CheckDestroy:             checkResourceIndexTemplateDestroy,
 		ProtoV5ProviderFactories: acctest.Providers,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceIndexTemplateCreate(templateName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "name", templateName),
-					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_index_template.test", "index_patterns.*", fmt.Sprintf("%s-logs-*", templateName)),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "priority", "42"),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.alias.#", "1"),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "name", fmt.Sprintf("%s-stream", templateName)),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "data_stream.0.hidden", "true"),
-				),
-			},
-			{
-				Config: testAccResourceIndexTemplateUpdate(templateName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "name", templateName),
-					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_index_template.test", "index_patterns.*", fmt.Sprintf("%s-logs-*", templateName)),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.alias.#", "2"),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "name", fmt.Sprintf("%s-stream", templateName)),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "data_stream.0.hidden", "false"),
-				),
-			},
-		},
+		Steps:                    testAccResourceIndexTemplateSteps(templateName),
+	})
+}
+
+func TestResourceIndexTemplate(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceIndexTemplateDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceIndexTemplateSteps("template"),
 	})
 }
 
+func testAccResourceIndexTemplateSteps(templateName string) []resource.TestStep {
+	return []resource.TestStep{
+		{
+			Config: testAccResourceIndexTemplateCreate(templateName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "name", templateName),
+				resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_index_template.test", "index_patterns.*", fmt.Sprintf("%s-logs-*", templateName)),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "priority", "42"),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.alias.#", "1"),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "name", fmt.Sprintf("%s-stream", templateName)),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "data_stream.0.hidden", "true"),
+			),
+		},
+		{
+			Config: testAccResourceIndexTemplateUpdate(templateName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "name", templateName),
+				resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_index_template.test", "index_patterns.*", fmt.Sprintf("%s-logs-*", templateName)),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test", "template.0.alias.#", "2"),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "name", fmt.Sprintf("%s-stream", templateName)),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_index_template.test2", "data_stream.0.hidden", "false"),
+			),
+		},
+	}
+}
+
 func testAccResourceIndexTemplateCreate(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -67,7 +67,7 @@ func ResourceIngestPipeline() *schema.Resource {
 
 	utils.AddConnectionSchema(pipelineSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Manages tasks and resources related to ingest pipelines and processors. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest-apis.html",
 
 		CreateContext: resourceIngestPipelineTemplatePut,
@@ -80,7 +80,7 @@ func ResourceIngestPipeline() *schema.Resource {
 		},
 
 		Schema: pipelineSchema,
-	}
+	})
 }
 
 func resourceIngestPipelineTemplatePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
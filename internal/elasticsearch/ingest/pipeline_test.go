@@ -5,40 +5,58 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceIngestPipeline(t *testing.T) {
+	cassette.Start(t)
 	pipelineName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
 		CheckDestroy:             checkResourceIngestPipelineDestroy,
 		ProtoV5ProviderFactories: acctest.Providers,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceIngestPipelineCreate(pipelineName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "name", pipelineName),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "description", "Test Pipeline"),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.#", "2"),
-				),
-			},
-			{
-				Config: testAccResourceIngestPipelineUpdate(pipelineName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "name", pipelineName),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "description", "Test Pipeline"),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.#", "1"),
-				),
-			},
-		},
+		Steps:                    testAccResourceIngestPipelineSteps(pipelineName),
+	})
+}
+
+func TestResourceIngestPipeline(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceIngestPipelineDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceIngestPipelineSteps("pipeline"),
 	})
 }
 
+func testAccResourceIngestPipelineSteps(pipelineName string) []resource.TestStep {
+	return []resource.TestStep{
+		{
+			Config: testAccResourceIngestPipelineCreate(pipelineName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "name", pipelineName),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "description", "Test Pipeline"),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.#", "2"),
+			),
+		},
+		{
+			Config: testAccResourceIngestPipelineUpdate(pipelineName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "name", pipelineName),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "description", "Test Pipeline"),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_ingest_pipeline.test_pipeline", "processors.#", "1"),
+			),
+		},
+	}
+}
+
 func testAccResourceIngestPipelineCreate(name string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
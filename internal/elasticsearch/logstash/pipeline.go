@@ -166,7 +166,7 @@ func ResourceLogstashPipeline() *schema.Resource {
 
 	utils.AddConnectionSchema(logstashPipelineSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Manage Logstash Pipelines via Centralized Pipeline Management. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/logstash-apis.html",
 
 		CreateContext: resourceLogstashPipelinePut,
@@ -179,7 +179,7 @@ func ResourceLogstashPipeline() *schema.Resource {
 		},
 
 		Schema: logstashPipelineSchema,
-	}
+	})
 }
 
 func resourceLogstashPipelinePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
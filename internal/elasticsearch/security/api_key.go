@@ -3,21 +3,32 @@ package security
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var APIKeyMinVersion = version.Must(version.NewVersion("8.0.0")) // Enabled in 8.0
 
+// APIKeyUpdateMinVersion is the first version updating the role descriptors and metadata of API keys in place.
+var APIKeyUpdateMinVersion = version.Must(version.NewVersion("8.4.0"))
+
+var apiKeyVersionRequirements = versionutils.Requirements{
+	"metadata": versionutils.Min("7.13.0"),
+}
+
 func ResourceApiKey() *schema.Resource {
 	apikeySchema := map[string]*schema.Schema{
 		"id": {
@@ -36,10 +47,9 @@ func ResourceApiKey() *schema.Resource {
 			),
 		},
 		"role_descriptors": {
-			Description:      "Role descriptors for this API key.",
+			Description:      "Role descriptors for this API key. Updated in place from Elasticsearch 8.4, the API key is replaced on older versions.",
 			Type:             schema.TypeString,
 			Optional:         true,
-			ForceNew:         true,
 			ValidateFunc:     validation.StringIsJSON,
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
@@ -55,14 +65,49 @@ func ResourceApiKey() *schema.Resource {
 			Computed:    true,
 		},
 		"metadata": {
-			Description:      "Arbitrary metadata that you want to associate with the API key.",
+			Description:      "Arbitrary metadata that you want to associate with the API key. Updated in place from Elasticsearch 8.4, the API key is replaced on older versions.",
 			Type:             schema.TypeString,
 			Optional:         true,
 			Computed:         true,
-			ForceNew:         true,
 			ValidateFunc:     validation.StringIsJSON,
 			DiffSuppressFunc: utils.DiffJsonSuppress,
 		},
+		"rotation_period": {
+			Description:  "Period after which the API key is rotated, using Elastic time units, e.g. `30d`. The first apply once the period has elapsed since the creation of the API key creates a new key, keeping the previous one valid for the `rotation_overlap`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"rotation_overlap": {
+			Description:  "Period during which the previous API key stays valid after a rotation, using Elastic time units, e.g. `7d`. The previous API key is invalidated by the first apply once the overlap has elapsed, or by the apply following the rotation when unset.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: utils.StringIsElasticDuration,
+		},
+		"keepers": {
+			Description: "Arbitrary map of values that, when changed, rotate the API key.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"creation_timestamp": {
+			Description: "Creation time in milliseconds of the API key, which is also the time of its last rotation.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"previous_api_key_id": {
+			Description: "Identifier of the API key replaced by the last rotation, until it is invalidated.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"previous_encoded": {
+			Description: "The `encoded` credentials of the API key replaced by the last rotation, until it is invalidated.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
 		"api_key": {
 			Description: "Generated API Key.",
 			Type:        schema.TypeString,
@@ -79,7 +124,7 @@ func ResourceApiKey() *schema.Resource {
 
 	utils.AddConnectionSchema(apikeySchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(apiKeyVersionRequirements.Apply(&schema.Resource{
 		Description: "Creates an API key for access without requiring basic authentication. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-create-api-key.html",
 
 		CreateContext: resourceSecurityApiKeyCreate,
@@ -87,8 +132,17 @@ func ResourceApiKey() *schema.Resource {
 		ReadContext:   resourceSecurityApiKeyRead,
 		DeleteContext: resourceSecurityApiKeyDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecurityApiKeyImport,
+		},
+
 		Schema: apikeySchema,
-	}
+
+		CustomizeDiff: customdiff.All(
+			versionutils.ForceNewBefore(APIKeyUpdateMinVersion, "role_descriptors", "metadata"),
+			resourceSecurityApiKeyRotationDiff,
+		),
+	}))
 }
 
 func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -97,32 +151,32 @@ func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	nameId := d.Get("name").(string)
-
-	var apikey models.ApiKey
-	apikey.Name = nameId
-
-	if v, ok := d.GetOk("expiration"); ok {
-		apikey.Expiration = v.(string)
+	if diags := putApiKey(ctx, client, d); diags.HasError() {
+		return diags
 	}
+	return resourceSecurityApiKeyRead(ctx, d, meta)
+}
 
-	if v, ok := d.GetOk("role_descriptors"); ok {
-		role_descriptors := map[string]models.Role{}
-		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&role_descriptors); err != nil {
-			return diag.FromErr(err)
-		}
-		apikey.RolesDescriptors = role_descriptors
+// putApiKey creates a new API key from the configuration, and sets the resource ID and credentials of the new key.
+func putApiKey(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData) diag.Diagnostics {
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := apiKeyVersionRequirements.Check(d, serverVersion, serverFlavor); diags.HasError() {
+		return diags
 	}
 
-	if v, ok := d.GetOk("metadata"); ok {
-		metadata := make(map[string]interface{})
-		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
-			return diag.FromErr(err)
-		}
-		apikey.Metadata = metadata
+	apikey, diags := expandApiKey(d)
+	if diags.HasError() {
+		return diags
 	}
 
-	putResponse, diags := elasticsearch.PutApiKey(client, &apikey)
+	putResponse, diags := elasticsearch.PutApiKey(ctx, client, apikey)
 
 	if diags.HasError() {
 		return diags
@@ -152,17 +206,180 @@ func resourceSecurityApiKeyCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.SetId(id.String())
-	return resourceSecurityApiKeyRead(ctx, d, meta)
+	return nil
 }
 
 func resourceSecurityApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	diags := diag.Diagnostics{diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  `Cannot update API Key`,
-		Detail:   `update not currently supported.`,
-	}}
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
 
-	return diags
+	if rotationPlanned(d) {
+		if diags := rotateApiKey(ctx, client, d); diags.HasError() {
+			return diags
+		}
+		return resourceSecurityApiKeyRead(ctx, d, meta)
+	}
+
+	// The overlap of the previous API key has elapsed.
+	if d.HasChange("previous_api_key_id") {
+		previousID, _ := d.GetChange("previous_api_key_id")
+		if diags := elasticsearch.DeleteApiKey(ctx, client, previousID.(string)); diags.HasError() {
+			return diags
+		}
+	}
+
+	if !d.HasChanges("role_descriptors", "metadata") {
+		return resourceSecurityApiKeyRead(ctx, d, meta)
+	}
+
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if serverVersion.LessThan(APIKeyUpdateMinVersion) {
+		return diag.Errorf("updating an API key requires Elasticsearch %s or later, current version is %s", APIKeyUpdateMinVersion, serverVersion)
+	}
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := apiKeyVersionRequirements.Check(d, serverVersion, serverFlavor); diags.HasError() {
+		return diags
+	}
+
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	apikey, diags := expandApiKey(d)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := elasticsearch.UpdateApiKey(ctx, client, compId.ResourceId, apikey); diags.HasError() {
+		return diags
+	}
+
+	return resourceSecurityApiKeyRead(ctx, d, meta)
+}
+
+// resourceSecurityApiKeyRotationDiff plans the rotation of the API key once its `rotation_period` has elapsed or its
+// `keepers` changed, and the invalidation of the previous API key once the `rotation_overlap` has elapsed.
+func resourceSecurityApiKeyRotationDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	creation := d.Get("creation_timestamp").(int)
+	rotatedAt := time.UnixMilli(int64(creation))
+	rotate := d.HasChange("keepers")
+	// The creation time is unknown until the API key is read.
+	if period, ok := d.GetOk("rotation_period"); ok && !rotate && creation != 0 {
+		duration, err := utils.ParseElasticDuration(period.(string))
+		if err != nil {
+			return err
+		}
+		rotate = !time.Now().Before(rotatedAt.Add(duration))
+	}
+
+	if rotate {
+		compId, diags := clients.CompositeIdFromStr(d.Id())
+		if diags.HasError() {
+			return fmt.Errorf("failed to parse the API key ID %q", d.Id())
+		}
+		// The credentials of the new API key are only known once it is created.
+		for _, key := range []string{"api_key", "encoded", "creation_timestamp", "expiration_timestamp"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		if err := d.SetNew("previous_api_key_id", compId.ResourceId); err != nil {
+			return err
+		}
+		return d.SetNew("previous_encoded", d.Get("encoded"))
+	}
+
+	if d.Get("previous_api_key_id").(string) == "" {
+		return nil
+	}
+	var overlap time.Duration
+	if v, ok := d.GetOk("rotation_overlap"); ok {
+		duration, err := utils.ParseElasticDuration(v.(string))
+		if err != nil {
+			return err
+		}
+		overlap = duration
+	}
+	if time.Now().Before(rotatedAt.Add(overlap)) {
+		return nil
+	}
+	if err := d.SetNew("previous_api_key_id", ""); err != nil {
+		return err
+	}
+	return d.SetNew("previous_encoded", "")
+}
+
+// rotationPlanned returns whether the plan rotates the API key: its credentials are only unknown when a new key is
+// created.
+func rotationPlanned(d *schema.ResourceData) bool {
+	plan := d.GetRawPlan()
+	return !plan.IsNull() && plan.IsKnown() && !plan.GetAttr("encoded").IsKnown()
+}
+
+// rotateApiKey replaces the API key with a new one, keeping the replaced key as the previous API key. A previous API
+// key which hasn't been invalidated yet is invalidated first. The resource ID is updated to the new API key: planning
+// it as unknown would replace the resource instead.
+func rotateApiKey(ctx context.Context, client *clients.ApiClient, d *schema.ResourceData) diag.Diagnostics {
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	if previousID, _ := d.GetChange("previous_api_key_id"); previousID.(string) != "" {
+		if diags := elasticsearch.DeleteApiKey(ctx, client, previousID.(string)); diags.HasError() {
+			return diags
+		}
+	}
+	previousEncoded, _ := d.GetChange("encoded")
+
+	if diags := putApiKey(ctx, client, d); diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("previous_api_key_id", compId.ResourceId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("previous_encoded", previousEncoded); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandApiKey(d *schema.ResourceData) (*models.ApiKey, diag.Diagnostics) {
+	var apikey models.ApiKey
+	apikey.Name = d.Get("name").(string)
+
+	if v, ok := d.GetOk("expiration"); ok {
+		apikey.Expiration = v.(string)
+	}
+
+	if v, ok := d.GetOk("role_descriptors"); ok {
+		role_descriptors := map[string]models.Role{}
+		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&role_descriptors); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		apikey.RolesDescriptors = role_descriptors
+	}
+
+	if v, ok := d.GetOk("metadata"); ok {
+		metadata := make(map[string]interface{})
+		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		apikey.Metadata = metadata
+	}
+
+	return &apikey, nil
 }
 
 func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -176,7 +393,7 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 	}
 	id := compId.ResourceId
 
-	apikey, diags := elasticsearch.GetApiKey(client, id)
+	apikey, diags := elasticsearch.GetApiKey(ctx, client, id)
 	if apikey == nil && diags == nil {
 		d.SetId("")
 		return diags
@@ -197,6 +414,9 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 	if err := d.Set("expiration_timestamp", apikey.Expiration); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("creation_timestamp", apikey.Creation); err != nil {
+		return diag.FromErr(err)
+	}
 
 	if apikey.RolesDescriptors != nil {
 		rolesDescriptors, err := json.Marshal(apikey.RolesDescriptors)
@@ -215,6 +435,29 @@ func resourceSecurityApiKeyRead(ctx context.Context, d *schema.ResourceData, met
 	return diags
 }
 
+// resourceSecurityApiKeyImport checks the imported API key is still valid. The `api_key` and `encoded` credentials
+// are only returned when the key is created, so they stay empty once imported.
+func resourceSecurityApiKeyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to create API client: %v", diags)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to parse provided ID, expected <cluster_uuid>/<api_key_id>")
+	}
+
+	apikey, diags := elasticsearch.GetApiKey(ctx, client, compId.ResourceId)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to import API key %q: %s", compId.ResourceId, diags[0].Detail)
+	}
+	if apikey.Invalidated {
+		return nil, fmt.Errorf("API key %q has been invalidated and can't be imported", compId.ResourceId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceSecurityApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, diags := clients.NewApiClient(d, meta)
 	if diags.HasError() {
@@ -225,9 +468,14 @@ func resourceSecurityApiKeyDelete(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	if diags := elasticsearch.DeleteApiKey(client, compId.ResourceId); diags.HasError() {
+	if diags := elasticsearch.DeleteApiKey(ctx, client, compId.ResourceId); diags.HasError() {
 		return diags
 	}
+	if previousID, ok := d.GetOk("previous_api_key_id"); ok {
+		if diags := elasticsearch.DeleteApiKey(ctx, client, previousID.(string)); diags.HasError() {
+			return diags
+		}
+	}
 
 	d.SetId("")
 	return diags
@@ -1,13 +1,18 @@
 package security_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
@@ -15,12 +20,114 @@ import (
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccResourceSecuritApiKey(t *testing.T) {
 	// generate a random name
+	cassette.Start(t)
 	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceSecurityApiKeySteps(apiKeyName),
+	})
+}
+
+func TestResourceSecurityApiKey(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceSecurityApiKeySteps("api-key"),
+	})
+}
+
+func testAccResourceSecurityApiKeySteps(apiKeyName string) []resource.TestStep {
+	var apiKeyID string
+	return []resource.TestStep{
+		{
+			SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyMinVersion),
+			Config:   testAccResourceSecuritApiKeyCreate(apiKeyName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "name", apiKeyName),
+				resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "role_descriptors", func(testValue string) error {
+					var testRoleDescriptor map[string]models.Role
+					if err := json.Unmarshal([]byte(testValue), &testRoleDescriptor); err != nil {
+						return err
+					}
+
+					allowRestrictedIndices := false
+					expectedRoleDescriptor := map[string]models.Role{
+						"role-a": {
+							Cluster: []string{"all"},
+							Indices: []models.IndexPerms{{
+								Names:                  []string{"index-a*"},
+								Privileges:             []string{"read"},
+								AllowRestrictedIndices: &allowRestrictedIndices,
+							}},
+						},
+					}
+
+					if !reflect.DeepEqual(testRoleDescriptor, expectedRoleDescriptor) {
+						return fmt.Errorf("%v doesn't match %v", testRoleDescriptor, expectedRoleDescriptor)
+					}
+
+					return nil
+				}),
+				resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "expiration"),
+				resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "api_key"),
+				resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "encoded"),
+				resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+					apiKeyID = value
+					return nil
+				}),
+			),
+		},
+		{
+			SkipFunc:          versionutils.CheckIfVersionIsUnsupported(security.APIKeyMinVersion),
+			Config:            testAccResourceSecuritApiKeyCreate(apiKeyName),
+			ResourceName:      "elasticstack_elasticsearch_security_api_key.test",
+			ImportState:       true,
+			ImportStateVerify: true,
+			// The credentials are only returned on create, and the expiration is only known as a timestamp.
+			ImportStateVerifyIgnore: []string{"api_key", "encoded", "expiration"},
+		},
+		{
+			SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyUpdateMinVersion),
+			Config:   testAccResourceSecuritApiKeyUpdate(apiKeyName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+					if value != apiKeyID {
+						return fmt.Errorf("expected the API key %s to be updated in place, got %s", apiKeyID, value)
+					}
+					return nil
+				}),
+				resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "role_descriptors", func(testValue string) error {
+					var testRoleDescriptor map[string]models.Role
+					if err := json.Unmarshal([]byte(testValue), &testRoleDescriptor); err != nil {
+						return err
+					}
+					if privileges := testRoleDescriptor["role-a"].Indices[0].Privileges; !reflect.DeepEqual(privileges, []string{"read", "view_index_metadata"}) {
+						return fmt.Errorf("unexpected privileges %v", privileges)
+					}
+					return nil
+				}),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "metadata", `{"env":"testing"}`),
+			),
+		},
+	}
+}
+
+func TestAccResourceSecurityApiKeyRotation(t *testing.T) {
+	cassette.Start(t)
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var apiKeyID, encoded string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
 		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
@@ -28,42 +135,136 @@ func TestAccResourceSecuritApiKey(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyMinVersion),
-				Config:   testAccResourceSecuritApiKeyCreate(apiKeyName),
+				Config:   testAccResourceSecurityApiKeyRotation(apiKeyName, "1"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "name", apiKeyName),
-					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "role_descriptors", func(testValue string) error {
-						var testRoleDescriptor map[string]models.Role
-						if err := json.Unmarshal([]byte(testValue), &testRoleDescriptor); err != nil {
-							return err
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "creation_timestamp"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "previous_api_key_id", ""),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+						apiKeyID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "encoded", func(value string) error {
+						encoded = value
+						return nil
+					}),
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyMinVersion),
+				Config:   testAccResourceSecurityApiKeyRotation(apiKeyName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "id", func(value string) error {
+						if value == apiKeyID {
+							return fmt.Errorf("expected the API key %s to be rotated", apiKeyID)
 						}
-
-						allowRestrictedIndices := false
-						expectedRoleDescriptor := map[string]models.Role{
-							"role-a": {
-								Cluster: []string{"all"},
-								Indices: []models.IndexPerms{{
-									Names:                  []string{"index-a*"},
-									Privileges:             []string{"read"},
-									AllowRestrictedIndices: &allowRestrictedIndices,
-								}},
-							},
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "previous_api_key_id", func(value string) error {
+						if compId, _ := clients.CompositeIdFromStr(apiKeyID); value != compId.ResourceId {
+							return fmt.Errorf("expected the previous API key to be %s, got %s", apiKeyID, value)
 						}
-
-						if !reflect.DeepEqual(testRoleDescriptor, expectedRoleDescriptor) {
-							return fmt.Errorf("%v doesn't match %v", testRoleDescriptor, expectedRoleDescriptor)
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_api_key.test", "previous_encoded", func(value string) error {
+						if value != encoded {
+							return fmt.Errorf("expected the previous encoded credentials to be kept")
 						}
-
 						return nil
 					}),
-					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "expiration"),
-					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "api_key"),
-					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_api_key.test", "encoded"),
+				),
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.APIKeyMinVersion),
+				Config:   testAccResourceSecurityApiKeyRotation(apiKeyName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "previous_api_key_id", ""),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_api_key.test", "previous_encoded", ""),
+					func(s *terraform.State) error {
+						client, err := clients.NewAcceptanceTestingClient()
+						if err != nil {
+							return err
+						}
+						compId, _ := clients.CompositeIdFromStr(apiKeyID)
+						apiKey, diags := elasticsearch.GetApiKey(context.Background(), client, compId.ResourceId)
+						if diags.HasError() {
+							return fmt.Errorf("Unabled to get API key %v", diags)
+						}
+						if !apiKey.Invalidated {
+							return fmt.Errorf("the previous API key %s has not been invalidated", compId.ResourceId)
+						}
+						return nil
+					},
 				),
 			},
 		},
 	})
 }
 
+func TestResourceSecurityApiKeyRotationDiff(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name            string
+		created         time.Time
+		previousID      string
+		rotationPeriod  string
+		rotationOverlap string
+		keeper          string
+		wantRotation    bool
+		wantInvalidated bool
+	}{
+		{name: "rotation period not elapsed", created: now.Add(-time.Hour), rotationPeriod: "1d"},
+		{name: "rotation period elapsed", created: now.Add(-25 * time.Hour), rotationPeriod: "1d", wantRotation: true},
+		{name: "keepers changed", created: now, keeper: "2", wantRotation: true},
+		{name: "previous API key kept during the overlap", created: now.Add(-time.Hour), previousID: "previous-key", rotationOverlap: "1d"},
+		{name: "previous API key invalidated after the overlap", created: now.Add(-2 * time.Hour), previousID: "previous-key", rotationOverlap: "1h", wantInvalidated: true},
+		{name: "previous API key invalidated by the next apply without overlap", created: now, previousID: "previous-key", wantInvalidated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: "cluster-uuid/key-id", Attributes: map[string]string{
+				"id":                  "cluster-uuid/key-id",
+				"name":                "key",
+				"encoded":             "encoded-key",
+				"metadata":            "{}",
+				"creation_timestamp":  strconv.FormatInt(tt.created.UnixMilli(), 10),
+				"previous_api_key_id": tt.previousID,
+				"rotation_period":     tt.rotationPeriod,
+				"rotation_overlap":    tt.rotationOverlap,
+				"keepers.%":           "1",
+				"keepers.version":     "1",
+			}}
+			keeper := tt.keeper
+			if keeper == "" {
+				keeper = "1"
+			}
+			config := map[string]interface{}{"name": "key", "keepers": map[string]interface{}{"version": keeper}}
+			if tt.rotationPeriod != "" {
+				config["rotation_period"] = tt.rotationPeriod
+			}
+			if tt.rotationOverlap != "" {
+				config["rotation_overlap"] = tt.rotationOverlap
+			}
+
+			diff, err := security.ResourceApiKey().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+			require.NoError(t, err)
+
+			encoded := diff.Attributes["encoded"]
+			require.Equal(t, tt.wantRotation, encoded != nil && encoded.NewComputed)
+			previous, ok := diff.Attributes["previous_api_key_id"]
+			switch {
+			case tt.wantRotation:
+				require.True(t, ok)
+				require.Equal(t, "key-id", previous.New)
+			case tt.wantInvalidated:
+				require.True(t, ok)
+				require.Equal(t, "", previous.New)
+			default:
+				require.False(t, ok, "unexpected diff %v", previous)
+			}
+		})
+	}
+}
+
 func testAccResourceSecuritApiKeyCreate(apiKeyName string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
@@ -89,6 +290,35 @@ resource "elasticstack_elasticsearch_security_api_key" "test" {
 	`, apiKeyName)
 }
 
+func testAccResourceSecuritApiKeyUpdate(apiKeyName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key" "test" {
+  name = "%s"
+
+  role_descriptors = jsonencode({
+    role-a = {
+      cluster = ["all"]
+      indices = [{
+        names = ["index-a*"]
+        privileges = ["read", "view_index_metadata"]
+        allow_restricted_indices = false
+      }]
+    }
+  })
+
+  metadata = jsonencode({
+    env = "testing"
+  })
+
+	expiration = "1d"
+}
+	`, apiKeyName)
+}
+
 func checkResourceSecurityApiKeyDestroy(s *terraform.State) error {
 	client, err := clients.NewAcceptanceTestingClient()
 	if err != nil {
@@ -101,7 +331,7 @@ func checkResourceSecurityApiKeyDestroy(s *terraform.State) error {
 		}
 		compId, _ := clients.CompositeIdFromStr(rs.Primary.ID)
 
-		apiKey, diags := elasticsearch.GetApiKey(client, compId.ResourceId)
+		apiKey, diags := elasticsearch.GetApiKey(context.Background(), client, compId.ResourceId)
 		if diags.HasError() {
 			return fmt.Errorf("Unabled to get API key %v", diags)
 		}
@@ -112,3 +342,20 @@ func checkResourceSecurityApiKeyDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func testAccResourceSecurityApiKeyRotation(apiKeyName, keeper string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_api_key" "test" {
+  name = "%s"
+
+  rotation_period = "30d"
+  keepers = {
+    version = "%s"
+  }
+}
+	`, apiKeyName, keeper)
+}
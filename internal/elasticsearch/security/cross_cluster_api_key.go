@@ -0,0 +1,393 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// CrossClusterAPIKeyMinVersion is the first version supporting cross-cluster API keys.
+var CrossClusterAPIKeyMinVersion = version.Must(version.NewVersion("8.10.0"))
+
+const crossClusterApiKeyType = "cross_cluster"
+
+func ResourceCrossClusterApiKey() *schema.Resource {
+	apikeySchema := map[string]*schema.Schema{
+		"id": {
+			Description: "Internal identifier of the resource.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Specifies the name for this API key.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 1024),
+				validation.StringMatch(regexp.MustCompile(`^([[:graph:]]| )+$`), "must contain alphanumeric characters (a-z, A-Z, 0-9), spaces, punctuation, and printable symbols in the Basic Latin (ASCII) block. Leading or trailing whitespace is not allowed"),
+			),
+		},
+		"access": {
+			Description: "The indices the remote cluster is allowed to search or replicate from this cluster. At least one `search` or `replication` entry must be set.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"search": {
+						Description:  "The indices which can be searched with cross-cluster search.",
+						Type:         schema.TypeList,
+						Optional:     true,
+						AtLeastOneOf: []string{"access.0.search", "access.0.replication"},
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"names": {
+									Description: "A list of indices (or index name patterns) to which the permissions in this entry apply.",
+									Type:        schema.TypeSet,
+									Required:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+								"field_security": {
+									Description: "The document fields that the remote cluster has read access to.",
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"grant": {
+												Description: "List of the fields to grant the access to.",
+												Type:        schema.TypeSet,
+												Optional:    true,
+												Elem: &schema.Schema{
+													Type: schema.TypeString,
+												},
+											},
+											"except": {
+												Description: "List of the fields to which the grants will not be applied.",
+												Type:        schema.TypeSet,
+												Optional:    true,
+												Elem: &schema.Schema{
+													Type: schema.TypeString,
+												},
+											},
+										},
+									},
+								},
+								"query": {
+									Description:      "A search query that defines the documents the remote cluster has read access to.",
+									Type:             schema.TypeString,
+									Optional:         true,
+									ValidateFunc:     validation.StringIsJSON,
+									DiffSuppressFunc: utils.DiffJsonSuppress,
+								},
+								"allow_restricted_indices": {
+									Description: "Include matching restricted indices in names parameter. Usage is strongly discouraged as it can grant unrestricted operations on critical data, make the entire system unstable or leak sensitive information.",
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Default:     false,
+								},
+							},
+						},
+					},
+					"replication": {
+						Description:  "The indices which can be replicated with cross-cluster replication.",
+						Type:         schema.TypeList,
+						Optional:     true,
+						AtLeastOneOf: []string{"access.0.search", "access.0.replication"},
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"names": {
+									Description: "A list of indices (or index name patterns) to which the permissions in this entry apply.",
+									Type:        schema.TypeSet,
+									Required:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"expiration": {
+			Description: "Expiration time for the API key. By default, API keys never expire.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"expiration_timestamp": {
+			Description: "Expiration time in milliseconds for the API key. By default, API keys never expire.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"metadata": {
+			Description:      "Arbitrary metadata that you want to associate with the API key.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: utils.DiffJsonSuppress,
+		},
+		"api_key": {
+			Description: "Generated API Key.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
+		"encoded": {
+			Description: "API key credentials which is the Base64-encoding of the UTF-8 representation of the id and api_key joined by a colon (:). Used as the `cluster.remote.<alias>.credentials` of the remote cluster.",
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Computed:    true,
+		},
+	}
+
+	utils.AddConnectionSchema(apikeySchema)
+
+	return utils.WithTimeouts(versionutils.ResourceNotOnServerless(&schema.Resource{
+		Description: "Creates an API key granting a remote cluster access to this cluster with the API key based security model. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-create-cross-cluster-api-key.html",
+
+		CreateContext: resourceSecurityCrossClusterApiKeyCreate,
+		UpdateContext: resourceSecurityCrossClusterApiKeyUpdate,
+		ReadContext:   resourceSecurityCrossClusterApiKeyRead,
+		DeleteContext: resourceSecurityApiKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecurityCrossClusterApiKeyImport,
+		},
+
+		Schema: apikeySchema,
+	}))
+}
+
+func resourceSecurityCrossClusterApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	if serverVersion.LessThan(CrossClusterAPIKeyMinVersion) {
+		return diag.Errorf("cross-cluster API keys require Elasticsearch %s or later, current version is %s", CrossClusterAPIKeyMinVersion, serverVersion)
+	}
+
+	apikey, diags := expandCrossClusterApiKey(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	putResponse, diags := elasticsearch.PutCrossClusterApiKey(ctx, client, apikey)
+	if diags.HasError() {
+		return diags
+	}
+
+	id, diags := client.ID(ctx, putResponse.Id)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("api_key", putResponse.Key); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("encoded", putResponse.EncodedKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiration", apikey.Expiration); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.String())
+	return resourceSecurityCrossClusterApiKeyRead(ctx, d, meta)
+}
+
+func resourceSecurityCrossClusterApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChanges("access", "metadata") {
+		return resourceSecurityCrossClusterApiKeyRead(ctx, d, meta)
+	}
+
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	apikey, diags := expandCrossClusterApiKey(d)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := elasticsearch.UpdateCrossClusterApiKey(ctx, client, compId.ResourceId, apikey); diags.HasError() {
+		return diags
+	}
+
+	return resourceSecurityCrossClusterApiKeyRead(ctx, d, meta)
+}
+
+func resourceSecurityCrossClusterApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	apikey, diags := elasticsearch.GetApiKey(ctx, client, compId.ResourceId)
+	if apikey == nil && diags == nil {
+		d.SetId("")
+		return diags
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("name", apikey.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiration_timestamp", apikey.Expiration); err != nil {
+		return diag.FromErr(err)
+	}
+	if apikey.Access != nil {
+		if err := d.Set("access", flattenCrossClusterApiKeyAccess(apikey.Access)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	metadata, err := json.Marshal(apikey.Metadata)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metadata", string(metadata)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceSecurityCrossClusterApiKeyImport checks the imported API key is a valid cross-cluster API key. The
+// `api_key` and `encoded` credentials are only returned when the key is created, so they stay empty once imported.
+func resourceSecurityCrossClusterApiKeyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to create API client: %v", diags)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to parse provided ID, expected <cluster_uuid>/<api_key_id>")
+	}
+
+	apikey, diags := elasticsearch.GetApiKey(ctx, client, compId.ResourceId)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to import API key %q: %s", compId.ResourceId, diags[0].Detail)
+	}
+	if apikey.Type != crossClusterApiKeyType {
+		return nil, fmt.Errorf("API key %q is not a cross-cluster API key, use elasticstack_elasticsearch_security_api_key to import it", compId.ResourceId)
+	}
+	if apikey.Invalidated {
+		return nil, fmt.Errorf("API key %q has been invalidated and can't be imported", compId.ResourceId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandCrossClusterApiKey(d *schema.ResourceData) (*models.CrossClusterApiKey, diag.Diagnostics) {
+	var apikey models.CrossClusterApiKey
+	apikey.Name = d.Get("name").(string)
+
+	if v, ok := d.GetOk("expiration"); ok {
+		apikey.Expiration = v.(string)
+	}
+
+	if v, ok := d.GetOk("access.0.search"); ok {
+		for _, e := range v.([]interface{}) {
+			entry := e.(map[string]interface{})
+			search := models.CrossClusterApiKeyAccessEntry{
+				Names: utils.ExpandStringSet(entry["names"].(*schema.Set)),
+			}
+			if fs, ok := entry["field_security"].([]interface{}); ok && len(fs) > 0 && fs[0] != nil {
+				fieldSecurity := fs[0].(map[string]interface{})
+				search.FieldSecurity = &models.FieldSecurity{
+					Grant:  utils.ExpandStringSet(fieldSecurity["grant"].(*schema.Set)),
+					Except: utils.ExpandStringSet(fieldSecurity["except"].(*schema.Set)),
+				}
+			}
+			if query := entry["query"].(string); query != "" {
+				search.Query = &query
+			}
+			if allowRestrictedIndices := entry["allow_restricted_indices"].(bool); allowRestrictedIndices {
+				search.AllowRestrictedIndices = &allowRestrictedIndices
+			}
+			apikey.Access.Search = append(apikey.Access.Search, search)
+		}
+	}
+
+	if v, ok := d.GetOk("access.0.replication"); ok {
+		for _, e := range v.([]interface{}) {
+			entry := e.(map[string]interface{})
+			apikey.Access.Replication = append(apikey.Access.Replication, models.CrossClusterApiKeyAccessEntry{
+				Names: utils.ExpandStringSet(entry["names"].(*schema.Set)),
+			})
+		}
+	}
+
+	if v, ok := d.GetOk("metadata"); ok {
+		metadata := make(map[string]interface{})
+		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		apikey.Metadata = metadata
+	}
+
+	return &apikey, nil
+}
+
+func flattenCrossClusterApiKeyAccess(access *models.CrossClusterApiKeyAccess) []interface{} {
+	search := make([]interface{}, len(access.Search))
+	for i, entry := range access.Search {
+		s := map[string]interface{}{
+			"names":                    entry.Names,
+			"allow_restricted_indices": entry.AllowRestrictedIndices != nil && *entry.AllowRestrictedIndices,
+		}
+		if entry.Query != nil {
+			s["query"] = *entry.Query
+		}
+		if entry.FieldSecurity != nil {
+			s["field_security"] = []interface{}{map[string]interface{}{
+				"grant":  entry.FieldSecurity.Grant,
+				"except": entry.FieldSecurity.Except,
+			}}
+		}
+		search[i] = s
+	}
+
+	replication := make([]interface{}, len(access.Replication))
+	for i, entry := range access.Replication {
+		replication[i] = map[string]interface{}{"names": entry.Names}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"search":      search,
+		"replication": replication,
+	}}
+}
@@ -0,0 +1,146 @@
+package security_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceSecurityCrossClusterApiKey(t *testing.T) {
+	cassette.Start(t)
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+	var apiKeyID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.CrossClusterAPIKeyMinVersion),
+				Config:   testAccResourceSecurityCrossClusterApiKeyCreate(apiKeyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "name", apiKeyName),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.search.#", "1"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.search.0.names.*", "logs-*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.replication.#", "0"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "api_key"),
+					resource.TestCheckResourceAttrSet("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "encoded"),
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "id", func(value string) error {
+						apiKeyID = value
+						return nil
+					}),
+				),
+			},
+			{
+				SkipFunc:          versionutils.CheckIfVersionIsUnsupported(security.CrossClusterAPIKeyMinVersion),
+				Config:            testAccResourceSecurityCrossClusterApiKeyCreate(apiKeyName),
+				ResourceName:      "elasticstack_elasticsearch_security_cross_cluster_api_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The credentials are only returned on create, and the expiration is only known as a timestamp.
+				ImportStateVerifyIgnore: []string{"api_key", "encoded", "expiration"},
+			},
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.CrossClusterAPIKeyMinVersion),
+				Config:   testAccResourceSecurityCrossClusterApiKeyUpdate(apiKeyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "id", func(value string) error {
+						if value != apiKeyID {
+							return fmt.Errorf("expected the API key %s to be updated in place, got %s", apiKeyID, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.search.0.field_security.0.grant.#", "2"),
+					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "access.0.replication.0.names.*", "archive-*"),
+					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_cross_cluster_api_key.test", "metadata", `{"env":"testing"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSecurityCrossClusterApiKeyImportNotCrossCluster(t *testing.T) {
+	cassette.Start(t)
+	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceSecurityApiKeyDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(security.CrossClusterAPIKeyMinVersion),
+				Config:   testAccResourceSecuritApiKeyCreate(apiKeyName),
+			},
+			{
+				SkipFunc:     versionutils.CheckIfVersionIsUnsupported(security.CrossClusterAPIKeyMinVersion),
+				Config:       testAccResourceSecuritApiKeyCreate(apiKeyName),
+				ResourceName: "elasticstack_elasticsearch_security_cross_cluster_api_key.imported",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["elasticstack_elasticsearch_security_api_key.test"].Primary.ID, nil
+				},
+				ExpectError: regexp.MustCompile(`is not a cross-cluster API key`),
+			},
+		},
+	})
+}
+
+func testAccResourceSecurityCrossClusterApiKeyCreate(apiKeyName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_cross_cluster_api_key" "test" {
+  name = "%s"
+
+  access {
+    search {
+      names = ["logs-*"]
+    }
+  }
+
+  expiration = "1d"
+}
+	`, apiKeyName)
+}
+
+func testAccResourceSecurityCrossClusterApiKeyUpdate(apiKeyName string) string {
+	return fmt.Sprintf(`
+provider "elasticstack" {
+  elasticsearch {}
+}
+
+resource "elasticstack_elasticsearch_security_cross_cluster_api_key" "test" {
+  name = "%s"
+
+  access {
+    search {
+      names = ["logs-*"]
+      field_security {
+        grant = ["@timestamp", "message"]
+      }
+    }
+    replication {
+      names = ["archive-*"]
+    }
+  }
+
+  metadata = jsonencode({
+    env = "testing"
+  })
+
+  expiration = "1d"
+}
+	`, apiKeyName)
+}
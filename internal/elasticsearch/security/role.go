@@ -157,7 +157,7 @@ func ResourceRole() *schema.Resource {
 
 	utils.AddConnectionSchema(roleSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Adds and updates roles in the native realm. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-put-role.html",
 
 		CreateContext: resourceSecurityRolePut,
@@ -170,7 +170,7 @@ func ResourceRole() *schema.Resource {
 		},
 
 		Schema: roleSchema,
-	}
+	})
 }
 
 func resourceSecurityRolePut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
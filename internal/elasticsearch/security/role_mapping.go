@@ -67,7 +67,7 @@ func ResourceRoleMapping() *schema.Resource {
 
 	utils.AddConnectionSchema(roleMappingSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Manage role mappings. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-put-role-mapping.html",
 
 		CreateContext: resourceSecurityRoleMappingPut,
@@ -80,7 +80,7 @@ func ResourceRoleMapping() *schema.Resource {
 		},
 
 		Schema: roleMappingSchema,
-	}
+	})
 }
 
 func resourceSecurityRoleMappingPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
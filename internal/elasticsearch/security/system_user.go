@@ -58,7 +58,7 @@ func ResourceSystemUser() *schema.Resource {
 
 	utils.AddConnectionSchema(userSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Updates system user's password and enablement. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/built-in-users.html",
 
 		CreateContext: resourceSecuritySystemUserPut,
@@ -66,8 +66,12 @@ func ResourceSystemUser() *schema.Resource {
 		ReadContext:   resourceSecuritySystemUserRead,
 		DeleteContext: resourceSecuritySystemUserDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecuritySystemUserImport,
+		},
+
 		Schema: userSchema,
-	}
+	})
 }
 
 func resourceSecuritySystemUserPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -151,6 +155,29 @@ func resourceSecuritySystemUserRead(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
+// resourceSecuritySystemUserImport checks the imported user is one of the reserved users built into Elasticsearch.
+// The password can't be read back, so it stays empty once imported.
+func resourceSecuritySystemUserImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, diags := clients.NewApiClient(d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to create API client: %v", diags)
+	}
+	compId, diags := clients.CompositeIdFromStr(d.Id())
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to parse provided ID, expected <cluster_uuid>/<username>")
+	}
+
+	user, diags := elasticsearch.GetUser(ctx, client, compId.ResourceId)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to import system user %q: %s", compId.ResourceId, diags[0].Detail)
+	}
+	if user == nil || !user.IsSystemUser() {
+		return nil, fmt.Errorf(`System user "%s" not found, use elasticstack_elasticsearch_security_user to import native users`, compId.ResourceId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceSecuritySystemUserDelete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	compId, diags := clients.CompositeIdFromStr(d.Id())
 	if diags.HasError() {
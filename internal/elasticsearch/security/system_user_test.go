@@ -27,6 +27,29 @@ func TestAccResourceSecuritySystemUser(t *testing.T) {
 					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_system_user.remote_monitoring_user", "enabled", "false"),
 				),
 			},
+			{
+				Config:                  testAccResourceSecuritySystemUserUpdate,
+				ResourceName:            "elasticstack_elasticsearch_security_system_user.remote_monitoring_user",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func TestAccResourceSecuritySystemUserImportNotSystemUser(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccResourceSecuritySystemUserNotFound,
+				ResourceName:  "elasticstack_elasticsearch_security_system_user.test",
+				ImportState:   true,
+				ImportStateId: "cluster-uuid/not_system_user",
+				ExpectError:   regexp.MustCompile(`System user "not_system_user" not found`),
+			},
 		},
 	})
 }
@@ -89,7 +89,7 @@ func ResourceUser() *schema.Resource {
 
 	utils.AddConnectionSchema(userSchema)
 
-	return &schema.Resource{
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Adds and updates users in the native realm. These users are commonly referred to as native users. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-put-user.html",
 
 		CreateContext: resourceSecurityUserPut,
@@ -102,7 +102,7 @@ func ResourceUser() *schema.Resource {
 		},
 
 		Schema: userSchema,
-	}
+	})
 }
 
 func resourceSecurityUserPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -17,30 +19,47 @@ import (
 
 func TestAccResourceSecurityUser(t *testing.T) {
 	// generate a random username
+	cassette.Start(t)
 	username := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
 		CheckDestroy:             checkResourceSecurityUserDestroy,
 		ProtoV5ProviderFactories: acctest.Providers,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceSecurityUserCreate(username),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "username", username),
-					resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_user.test", "roles.*", "kibana_user"),
-					resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "email", ""),
-				),
-			},
-			{
-				Config: testAccResourceSecurityUpdate(username, "kibana_user"),
-				Check:  resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "email", "test@example.com"),
-			},
-		},
+		Steps:                    testAccResourceSecurityUserSteps(username),
 	})
 }
 
+func TestResourceSecurityUser(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceSecurityUserDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceSecurityUserSteps("user"),
+	})
+}
+
+func testAccResourceSecurityUserSteps(username string) []resource.TestStep {
+	return []resource.TestStep{
+		{
+			Config: testAccResourceSecurityUserCreate(username),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "username", username),
+				resource.TestCheckTypeSetElemAttr("elasticstack_elasticsearch_security_user.test", "roles.*", "kibana_user"),
+				resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "email", ""),
+			),
+		},
+		{
+			Config: testAccResourceSecurityUpdate(username, "kibana_user"),
+			Check:  resource.TestCheckResourceAttr("elasticstack_elasticsearch_security_user.test", "email", "test@example.com"),
+		},
+	}
+}
+
 func TestAccImportedUserDoesNotResetPassword(t *testing.T) {
+	cassette.Start(t)
 	username := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 	initialPassword := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 	userUpdatedPassword := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
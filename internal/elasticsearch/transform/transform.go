@@ -12,6 +12,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,27 +20,23 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var settingsRequiredVersions map[string]*version.Version
-
-func init() {
-	settingsRequiredVersions = make(map[string]*version.Version)
-
+var transformVersionRequirements = versionutils.Requirements{
 	// capabilities
-	settingsRequiredVersions["destination.pipeline"] = version.Must(version.NewVersion("7.3.0"))
-	settingsRequiredVersions["frequency"] = version.Must(version.NewVersion("7.3.0"))
-	settingsRequiredVersions["latest"] = version.Must(version.NewVersion("7.11.0"))
-	settingsRequiredVersions["retention_policy"] = version.Must(version.NewVersion("7.12.0"))
-	settingsRequiredVersions["source.runtime_mappings"] = version.Must(version.NewVersion("7.12.0"))
-	settingsRequiredVersions["metadata"] = version.Must(version.NewVersion("7.16.0"))
+	"destination.0.pipeline":    versionutils.Min("7.3.0").WithWarning(),
+	"frequency":                 versionutils.Min("7.3.0").WithWarning().WithDefault("1m"),
+	"latest":                    versionutils.Min("7.11.0").WithWarning(),
+	"retention_policy":          versionutils.Min("7.12.0").WithWarning(),
+	"source.0.runtime_mappings": versionutils.Min("7.12.0").WithWarning(),
+	"metadata":                  versionutils.Min("7.16.0").WithWarning(),
 
 	// settings
-	settingsRequiredVersions["docs_per_second"] = version.Must(version.NewVersion("7.8.0"))
-	settingsRequiredVersions["max_page_search_size"] = version.Must(version.NewVersion("7.8.0"))
-	settingsRequiredVersions["dates_as_epoch_millis"] = version.Must(version.NewVersion("7.11.0"))
-	settingsRequiredVersions["align_checkpoints"] = version.Must(version.NewVersion("7.15.0"))
-	settingsRequiredVersions["deduce_mappings"] = version.Must(version.NewVersion("8.1.0"))
-	settingsRequiredVersions["num_failure_retries"] = version.Must(version.NewVersion("8.4.0"))
-	settingsRequiredVersions["unattended"] = version.Must(version.NewVersion("8.5.0"))
+	"docs_per_second":       versionutils.Min("7.8.0").WithWarning(),
+	"max_page_search_size":  versionutils.Min("7.8.0").WithWarning(),
+	"dates_as_epoch_millis": versionutils.Min("7.11.0").WithWarning(),
+	"align_checkpoints":     versionutils.Min("7.15.0").WithWarning(),
+	"deduce_mappings":       versionutils.Min("8.1.0").WithWarning(),
+	"num_failure_retries":   versionutils.Min("8.4.0").WithWarning(),
+	"unattended":            versionutils.Min("8.5.0").WithWarning(),
 }
 
 func ResourceTransform() *schema.Resource {
@@ -280,7 +277,7 @@ func ResourceTransform() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
+	return utils.WithTimeouts(transformVersionRequirements.Apply(&schema.Resource{
 		Schema:      transformSchema,
 		Description: "Manages Elasticsearch transforms. See: https://www.elastic.co/guide/en/elasticsearch/reference/current/transforms.html",
 
@@ -292,7 +289,7 @@ func ResourceTransform() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-	}
+	}))
 }
 
 func resourceTransformCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -313,6 +310,15 @@ func resourceTransformCreate(ctx context.Context, d *schema.ResourceData, meta i
 		return diags
 	}
 
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	diags = transformVersionRequirements.Check(d, serverVersion, serverFlavor)
+	if diags.HasError() {
+		return diags
+	}
+
 	transform, err := getTransformFromResourceData(ctx, d, transformName, serverVersion)
 	if err != nil {
 		return diag.FromErr(err)
@@ -334,7 +340,7 @@ func resourceTransformCreate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	d.SetId(id.String())
-	return resourceTransformRead(ctx, d, meta)
+	return append(diags, resourceTransformRead(ctx, d, meta)...)
 }
 
 func resourceTransformRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -400,6 +406,15 @@ func resourceTransformUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		return diags
 	}
 
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return diags
+	}
+	diags = transformVersionRequirements.Check(d, serverVersion, serverFlavor)
+	if diags.HasError() {
+		return diags
+	}
+
 	updatedTransform, err := getTransformFromResourceData(ctx, d, transformName, serverVersion)
 	if err != nil {
 		return diag.FromErr(err)
@@ -425,7 +440,7 @@ func resourceTransformUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		return diags
 	}
 
-	return resourceTransformRead(ctx, d, meta)
+	return append(diags, resourceTransformRead(ctx, d, meta)...)
 }
 
 func resourceTransformDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -475,7 +490,7 @@ func getTransformFromResourceData(ctx context.Context, d *schema.ResourceData, n
 			transform.Source.Query = query
 		}
 
-		if v, ok := definedSource["runtime_mappings"]; ok && len(v.(string)) > 0 && isSettingAllowed(ctx, "source.runtime_mappings", serverVersion) {
+		if v, ok := definedSource["runtime_mappings"]; ok && len(v.(string)) > 0 && transformVersionRequirements.Supports("source.0.runtime_mappings", serverVersion) {
 			var runtimeMappings interface{}
 			if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&runtimeMappings); err != nil {
 				return nil, err
@@ -492,7 +507,7 @@ func getTransformFromResourceData(ctx context.Context, d *schema.ResourceData, n
 			Index: definedDestination["index"].(string),
 		}
 
-		if pipeline, ok := definedDestination["pipeline"]; ok && isSettingAllowed(ctx, "destination.pipeline", serverVersion) {
+		if pipeline, ok := definedDestination["pipeline"]; ok && transformVersionRequirements.Supports("destination.0.pipeline", serverVersion) {
 			transform.Destination.Pipeline = pipeline.(string)
 		}
 	}
@@ -505,7 +520,7 @@ func getTransformFromResourceData(ctx context.Context, d *schema.ResourceData, n
 		transform.Pivot = pivot
 	}
 
-	if v, ok := d.GetOk("latest"); ok && isSettingAllowed(ctx, "latest", serverVersion) {
+	if v, ok := d.GetOk("latest"); ok && transformVersionRequirements.Supports("latest", serverVersion) {
 		var latest interface{}
 		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&latest); err != nil {
 			return nil, err
@@ -513,11 +528,11 @@ func getTransformFromResourceData(ctx context.Context, d *schema.ResourceData, n
 		transform.Latest = latest
 	}
 
-	if v, ok := d.GetOk("frequency"); ok && isSettingAllowed(ctx, "frequency", serverVersion) {
+	if v, ok := d.GetOk("frequency"); ok && transformVersionRequirements.Supports("frequency", serverVersion) {
 		transform.Frequency = v.(string)
 	}
 
-	if v, ok := d.GetOk("metadata"); ok && isSettingAllowed(ctx, "metadata", serverVersion) {
+	if v, ok := d.GetOk("metadata"); ok && transformVersionRequirements.Supports("metadata", serverVersion) {
 		var metadata map[string]interface{}
 		if err := json.NewDecoder(strings.NewReader(v.(string))).Decode(&metadata); err != nil {
 			return nil, err
@@ -525,7 +540,7 @@ func getTransformFromResourceData(ctx context.Context, d *schema.ResourceData, n
 		transform.Meta = metadata
 	}
 
-	if v, ok := d.GetOk("retention_policy"); ok && isSettingAllowed(ctx, "retention_policy", serverVersion) {
+	if v, ok := d.GetOk("retention_policy"); ok && transformVersionRequirements.Supports("retention_policy", serverVersion) {
 		definedRetentionPolicy := v.([]interface{})[0].(map[string]interface{})
 
 		if v, ok := definedRetentionPolicy["time"]; ok {
@@ -565,37 +580,37 @@ func getTransformFromResourceData(ctx context.Context, d *schema.ResourceData, n
 	settings := models.TransformSettings{}
 	setSettings := false
 
-	if v, ok := d.GetOk("align_checkpoints"); ok && isSettingAllowed(ctx, "align_checkpoints", serverVersion) {
+	if v, ok := d.GetOk("align_checkpoints"); ok && transformVersionRequirements.Supports("align_checkpoints", serverVersion) {
 		setSettings = true
 		ac := v.(bool)
 		settings.AlignCheckpoints = &ac
 	}
-	if v, ok := d.GetOk("dates_as_epoch_millis"); ok && isSettingAllowed(ctx, "dates_as_epoch_millis", serverVersion) {
+	if v, ok := d.GetOk("dates_as_epoch_millis"); ok && transformVersionRequirements.Supports("dates_as_epoch_millis", serverVersion) {
 		setSettings = true
 		dem := v.(bool)
 		settings.DatesAsEpochMillis = &dem
 	}
-	if v, ok := d.GetOk("deduce_mappings"); ok && isSettingAllowed(ctx, "deduce_mappings", serverVersion) {
+	if v, ok := d.GetOk("deduce_mappings"); ok && transformVersionRequirements.Supports("deduce_mappings", serverVersion) {
 		setSettings = true
 		dm := v.(bool)
 		settings.DeduceMappings = &dm
 	}
-	if v, ok := d.GetOk("docs_per_second"); ok && isSettingAllowed(ctx, "docs_per_second", serverVersion) {
+	if v, ok := d.GetOk("docs_per_second"); ok && transformVersionRequirements.Supports("docs_per_second", serverVersion) {
 		setSettings = true
 		dps := v.(float64)
 		settings.DocsPerSecond = &dps
 	}
-	if v, ok := d.GetOk("max_page_search_size"); ok && isSettingAllowed(ctx, "max_page_search_size", serverVersion) {
+	if v, ok := d.GetOk("max_page_search_size"); ok && transformVersionRequirements.Supports("max_page_search_size", serverVersion) {
 		setSettings = true
 		mpss := v.(int)
 		settings.MaxPageSearchSize = &mpss
 	}
-	if v, ok := d.GetOk("num_failure_retries"); ok && isSettingAllowed(ctx, "num_failure_retries", serverVersion) {
+	if v, ok := d.GetOk("num_failure_retries"); ok && transformVersionRequirements.Supports("num_failure_retries", serverVersion) {
 		setSettings = true
 		nfr := v.(int)
 		settings.NumFailureRetries = &nfr
 	}
-	if v, ok := d.GetOk("unattended"); ok && isSettingAllowed(ctx, "unattended", serverVersion) {
+	if v, ok := d.GetOk("unattended"); ok && transformVersionRequirements.Supports("unattended", serverVersion) {
 		setSettings = true
 		u := v.(bool)
 		settings.Unattended = &u
@@ -825,14 +840,3 @@ func flattenRetentionPolicy(retention *models.TransformRetentionPolicy) []interf
 
 	return []interface{}{r}
 }
-
-func isSettingAllowed(ctx context.Context, settingName string, serverVersion *version.Version) bool {
-	if minVersion, ok := settingsRequiredVersions[settingName]; ok {
-		if serverVersion.LessThan(minVersion) {
-			tflog.Warn(ctx, fmt.Sprintf("Setting [%s] not allowed for Elasticsearch server version %v; min required is %v", settingName, *serverVersion, *minVersion))
-			return false
-		}
-	}
-
-	return true
-}
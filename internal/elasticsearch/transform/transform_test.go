@@ -6,6 +6,7 @@ import (
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -13,6 +14,7 @@ import (
 
 func TestAccResourceTransformWithPivot(t *testing.T) {
 
+	cassette.Start(t)
 	transformNamePivot := sdkacctest.RandStringFromCharSet(18, sdkacctest.CharSetAlphaNum)
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
@@ -58,6 +60,7 @@ func TestAccResourceTransformWithPivot(t *testing.T) {
 
 func TestAccResourceTransformWithLatest(t *testing.T) {
 
+	cassette.Start(t)
 	transformNameLatest := sdkacctest.RandStringFromCharSet(20, sdkacctest.CharSetAlphaNum)
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
@@ -82,6 +85,7 @@ func TestAccResourceTransformWithLatest(t *testing.T) {
 
 func TestAccResourceTransformNoDefer(t *testing.T) {
 
+	cassette.Start(t)
 	transformName := sdkacctest.RandStringFromCharSet(18, sdkacctest.CharSetAlphaNum)
 	indexName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 	resource.Test(t, resource.TestCase{
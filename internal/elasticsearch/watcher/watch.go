@@ -9,6 +9,7 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
 	"github.com/elastic/terraform-provider-elasticstack/internal/models"
 	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -88,7 +89,7 @@ func ResourceWatch() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
+	return utils.WithTimeouts(versionutils.ResourceNotOnServerless(&schema.Resource{
 		Description: "Manage Watches. See, https://www.elastic.co/guide/en/elasticsearch/reference/current/watcher-api.html",
 
 		CreateContext: resourceWatchPut,
@@ -101,7 +102,7 @@ func ResourceWatch() *schema.Resource {
 		},
 
 		Schema: watchSchema,
-	}
+	}))
 }
 
 func resourceWatchPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,251 @@
+// Package export generates the Terraform configuration of the objects of an existing cluster, along with the
+// `import` blocks bringing them under management.
+//
+// The objects are read with the read function of their resource, so that the generated configuration matches
+// what the provider reads back after the import.
+package export
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/kibana"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/index"
+	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
+	kibanaresources "github.com/elastic/terraform-provider-elasticstack/internal/kibana"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// object is an object of the cluster to export.
+type object struct {
+	// name is used to build the resource label.
+	name string
+	// id is the import ID of the resource.
+	id string
+}
+
+type kind struct {
+	resourceType string
+	resource     func() *schema.Resource
+	list         func(ctx context.Context, client *clients.ApiClient, opts Options) ([]object, diag.Diagnostics)
+}
+
+// kinds are the kinds of objects which can be exported, keyed by the name used in the `--resources` flag.
+var kinds = map[string]kind{
+	"index_template": {
+		resourceType: "elasticstack_elasticsearch_index_template",
+		resource:     index.ResourceTemplate,
+		list:         listIndexTemplates,
+	},
+	"ilm": {
+		resourceType: "elasticstack_elasticsearch_index_lifecycle",
+		resource:     index.ResourceIlm,
+		list:         listIlm,
+	},
+	"role": {
+		resourceType: "elasticstack_elasticsearch_security_role",
+		resource:     security.ResourceRole,
+		list:         listRoles,
+	},
+	"alerting_rule": {
+		resourceType: "elasticstack_kibana_alerting_rule",
+		resource:     kibanaresources.ResourceAlertingRule,
+		list:         listAlertingRules,
+	},
+}
+
+// Kinds returns the names of the kinds of objects which can be exported.
+func Kinds() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Options select the objects to export.
+type Options struct {
+	// Resources are the kinds of objects to export, e.g. `index_template`. All kinds are exported when empty.
+	Resources []string
+	// SpaceID is the Kibana space of the exported alerting rules.
+	SpaceID string
+}
+
+// Export writes the resource and import blocks of the objects of the cluster to w. Built-in and system objects are
+// skipped.
+func Export(ctx context.Context, client *clients.ApiClient, opts Options, w io.Writer) diag.Diagnostics {
+	resources := opts.Resources
+	if len(resources) == 0 {
+		resources = Kinds()
+	}
+	if opts.SpaceID == "" {
+		opts.SpaceID = "default"
+	}
+
+	for _, name := range resources {
+		k, ok := kinds[name]
+		if !ok {
+			return diag.Errorf("unsupported resource %q, expected one of: %s", name, strings.Join(Kinds(), ", "))
+		}
+
+		objects, diags := k.list(ctx, client, opts)
+		if diags.HasError() {
+			return diags
+		}
+		sort.Slice(objects, func(i, j int) bool { return objects[i].name < objects[j].name })
+
+		labels := map[string]bool{}
+		for _, o := range objects {
+			r := k.resource()
+			d := r.Data(&terraform.InstanceState{ID: o.id})
+			if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+				return diags
+			}
+			// The object was deleted since it was listed.
+			if d.Id() == "" {
+				continue
+			}
+
+			values := make(map[string]interface{}, len(r.Schema))
+			for key := range r.Schema {
+				values[key] = d.Get(key)
+			}
+			config, err := writeConfig(k.resourceType, uniqueLabel(o.name, labels), o.id, r.Schema, values)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if _, err := w.Write(config); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	return nil
+}
+
+// Run runs the export command with its command line arguments, configuring the client from the environment
+// variables the provider reads.
+func Run(ctx context.Context, version string, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	resources := flags.String("resources", strings.Join(Kinds(), ","), "comma separated list of the kinds of objects to export")
+	spaceID := flags.String("space", "default", "Kibana space of the exported alerting rules")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := clients.NewApiClientFromEnv(version)
+	if err != nil {
+		return err
+	}
+
+	opts := Options{SpaceID: *spaceID}
+	for _, r := range strings.Split(*resources, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			opts.Resources = append(opts.Resources, r)
+		}
+	}
+
+	for _, d := range Export(ctx, client, opts, w) {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s %s", d.Summary, d.Detail)
+		}
+	}
+	return nil
+}
+
+// isSystem returns whether the object is built into the stack: its name starts with a dot, or its metadata flags
+// it as reserved or managed.
+func isSystem(name string, metadata map[string]interface{}, keys ...string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+	for _, key := range keys {
+		if v, ok := metadata[key].(bool); ok && v {
+			return true
+		}
+	}
+	return false
+}
+
+func listIndexTemplates(ctx context.Context, client *clients.ApiClient, _ Options) ([]object, diag.Diagnostics) {
+	templates, diags := elasticsearch.ListIndexTemplates(ctx, client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var objects []object
+	for _, tpl := range templates {
+		if isSystem(tpl.Name, tpl.IndexTemplate.Meta, "managed") {
+			continue
+		}
+		id, diags := client.ID(ctx, tpl.Name)
+		if diags.HasError() {
+			return nil, diags
+		}
+		objects = append(objects, object{name: tpl.Name, id: id.String()})
+	}
+	return objects, nil
+}
+
+func listIlm(ctx context.Context, client *clients.ApiClient, _ Options) ([]object, diag.Diagnostics) {
+	policies, diags := elasticsearch.ListIlm(ctx, client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var objects []object
+	for name, policy := range policies {
+		if isSystem(name, policy.Policy.Metadata, "managed") {
+			continue
+		}
+		id, diags := client.ID(ctx, name)
+		if diags.HasError() {
+			return nil, diags
+		}
+		objects = append(objects, object{name: name, id: id.String()})
+	}
+	return objects, nil
+}
+
+func listRoles(ctx context.Context, client *clients.ApiClient, _ Options) ([]object, diag.Diagnostics) {
+	roles, diags := elasticsearch.ListRoles(ctx, client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var objects []object
+	for name, role := range roles {
+		if isSystem(name, role.Metadata, "_reserved") {
+			continue
+		}
+		id, diags := client.ID(ctx, name)
+		if diags.HasError() {
+			return nil, diags
+		}
+		objects = append(objects, object{name: name, id: id.String()})
+	}
+	return objects, nil
+}
+
+func listAlertingRules(ctx context.Context, client *clients.ApiClient, opts Options) ([]object, diag.Diagnostics) {
+	rules, diags := kibana.ListAlertingRules(ctx, client, opts.SpaceID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var objects []object
+	for _, rule := range rules {
+		// The ID of the rule resource is made of the space and the rule IDs.
+		id := &clients.CompositeId{ClusterId: opts.SpaceID, ResourceId: rule.ID}
+		objects = append(objects, object{name: rule.Name, id: id.String()})
+	}
+	return objects, nil
+}
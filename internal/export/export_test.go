@@ -0,0 +1,100 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/elasticsearch"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/kibana"
+	"github.com/elastic/terraform-provider-elasticstack/internal/export"
+	"github.com/elastic/terraform-provider-elasticstack/internal/models"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	server := fake.NewServer(t)
+	server.Setenv(t)
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	priority := 100
+	for _, template := range []*models.IndexTemplate{
+		{Name: "logs-app", IndexPatterns: []string{"logs-app-*"}, ComposedOf: []string{}, Priority: &priority, Template: &models.Template{
+			Settings: map[string]interface{}{"index.number_of_shards": "1"},
+		}},
+		{Name: ".hidden", IndexPatterns: []string{".hidden-*"}, ComposedOf: []string{}},
+		{Name: "logs", IndexPatterns: []string{"logs-*-*"}, ComposedOf: []string{}, Meta: map[string]interface{}{"managed": true}},
+	} {
+		require.False(t, elasticsearch.PutIndexTemplate(ctx, client, template).HasError())
+	}
+
+	for _, policy := range []*models.Policy{
+		{Name: "rollover daily", Phases: map[string]models.Phase{
+			"hot": {Actions: map[string]models.Action{"rollover": {"max_age": "1d"}}},
+		}},
+		{Name: "logs", Metadata: map[string]interface{}{"managed": true}, Phases: map[string]models.Phase{
+			"hot": {Actions: map[string]models.Action{"rollover": {"max_age": "30d"}}},
+		}},
+	} {
+		require.False(t, elasticsearch.PutIlm(ctx, client, policy).HasError())
+	}
+
+	for _, role := range []*models.Role{
+		{Name: "reader", Cluster: []string{"monitor"}, Indices: []models.IndexPerms{{Names: []string{"logs-*"}, Privileges: []string{"read"}}}},
+		{Name: "superuser", Cluster: []string{"all"}, Metadata: map[string]interface{}{"_reserved": true}},
+	} {
+		require.False(t, elasticsearch.PutRole(ctx, client, role).HasError())
+	}
+
+	rule, diags := kibana.CreateAlertingRule(ctx, client, models.AlertingRule{
+		SpaceID:    "default",
+		Name:       "Index threshold",
+		Consumer:   "alerts",
+		NotifyWhen: "onActiveAlert",
+		RuleTypeID: ".index-threshold",
+		Schedule:   models.AlertingRuleSchedule{Interval: "1m"},
+		Params:     map[string]interface{}{"index": []interface{}{"logs-*"}},
+	})
+	require.False(t, diags.HasError(), diags)
+
+	var out bytes.Buffer
+	diags = export.Export(ctx, client, export.Options{}, &out)
+	require.False(t, diags.HasError(), diags)
+	config := out.String()
+
+	_, hclDiags := hclsyntax.ParseConfig(out.Bytes(), "export.tf", hcl.InitialPos)
+	require.False(t, hclDiags.HasErrors(), hclDiags.Error())
+
+	require.Contains(t, config, `resource "elasticstack_elasticsearch_index_template" "logs-app" {`)
+	require.Contains(t, config, `to = elasticstack_elasticsearch_index_template.logs-app`)
+	require.Contains(t, config, `id = "fake-cluster-uuid/logs-app"`)
+	require.Contains(t, config, "index = {\n        number_of_shards = \"1\"\n      }")
+	require.Contains(t, config, `resource "elasticstack_elasticsearch_index_lifecycle" "rollover_daily" {`)
+	require.Contains(t, config, `id = "fake-cluster-uuid/rollover daily"`)
+	require.Contains(t, config, `resource "elasticstack_elasticsearch_security_role" "reader" {`)
+	require.Contains(t, config, `id = "fake-cluster-uuid/reader"`)
+	require.Contains(t, config, `resource "elasticstack_kibana_alerting_rule" "Index_threshold" {`)
+	require.Contains(t, config, `id = "default/`+rule.ID+`"`)
+
+	require.NotContains(t, config, ".hidden")
+	require.NotContains(t, config, `"fake-cluster-uuid/logs"`)
+	require.NotContains(t, config, "superuser")
+}
+
+func TestExport_unsupportedResource(t *testing.T) {
+	server := fake.NewServer(t)
+	server.Setenv(t)
+	client, err := clients.NewAcceptanceTestingClient()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	diags := export.Export(context.Background(), client, export.Options{Resources: []string{"dashboard"}}, &out)
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Summary, `unsupported resource "dashboard"`)
+}
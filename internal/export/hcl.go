@@ -0,0 +1,233 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// skippedAttributes are never written to the configuration: the connection blocks aren't returned by the APIs.
+var skippedAttributes = map[string]bool{
+	"id":                       true,
+	"elasticsearch_connection": true,
+	"kibana_connection":        true,
+	"fleet_connection":         true,
+}
+
+// writeConfig returns the resource block of the object, followed by its import block.
+func writeConfig(resourceType, label, id string, s map[string]*schema.Schema, values map[string]interface{}) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+
+	resource := root.AppendNewBlock("resource", []string{resourceType, label})
+	if err := writeBody(resource.Body(), s, values); err != nil {
+		return nil, fmt.Errorf("unable to write the configuration of %s.%s: %w", resourceType, label, err)
+	}
+	root.AppendNewline()
+
+	imp := root.AppendNewBlock("import", nil)
+	imp.Body().SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	imp.Body().SetAttributeValue("id", cty.StringVal(id))
+	root.AppendNewline()
+
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+// writeBody writes the configurable attributes which are set, followed by the nested blocks.
+func writeBody(body *hclwrite.Body, s map[string]*schema.Schema, values map[string]interface{}) error {
+	keys := make([]string, 0, len(s))
+	for key, sch := range s {
+		if skippedAttributes[key] || (!sch.Required && !sch.Optional) || sch.Deprecated != "" || isDefault(sch, values[key]) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var blocks []string
+	for _, key := range keys {
+		sch := s[key]
+		if _, ok := sch.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, key)
+			continue
+		}
+
+		if v, ok := values[key].(string); ok && isJSONObject(v) {
+			tokens, err := jsonencode(v)
+			if err != nil {
+				return err
+			}
+			body.SetAttributeRaw(key, tokens)
+			continue
+		}
+		value, err := toCty(sch, values[key])
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", key, err)
+		}
+		body.SetAttributeValue(key, value)
+	}
+
+	for _, key := range blocks {
+		elem := s[key].Elem.(*schema.Resource)
+		for _, item := range listItems(values[key]) {
+			m, _ := item.(map[string]interface{})
+			block := body.AppendNewBlock(key, nil)
+			if err := writeBody(block.Body(), elem.Schema, m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isDefault returns whether the attribute is unset or set to its default, so that it can be left out.
+func isDefault(sch *schema.Schema, value interface{}) bool {
+	if sch.Required {
+		return false
+	}
+	if sch.Default != nil {
+		return reflect.DeepEqual(sch.Default, value)
+	}
+	switch v := value.(type) {
+	case nil:
+		return true
+	case *schema.Set:
+		return v.Len() == 0
+	case []interface{}:
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); !ok || len(m) > 0 {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return reflect.ValueOf(v).IsZero()
+	}
+}
+
+func listItems(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	default:
+		return nil
+	}
+}
+
+// toCty converts the value read from the resource data to the type of the attribute.
+func toCty(sch *schema.Schema, value interface{}) (cty.Value, error) {
+	switch sch.Type {
+	case schema.TypeString:
+		v, _ := value.(string)
+		return cty.StringVal(v), nil
+	case schema.TypeInt:
+		v, _ := value.(int)
+		return cty.NumberIntVal(int64(v)), nil
+	case schema.TypeFloat:
+		v, _ := value.(float64)
+		return cty.NumberFloatVal(v), nil
+	case schema.TypeBool:
+		v, _ := value.(bool)
+		return cty.BoolVal(v), nil
+	case schema.TypeList, schema.TypeSet:
+		elem := elemSchema(sch)
+		items := listItems(value)
+		if sch.Type == schema.TypeSet && elem.Type == schema.TypeString {
+			sort.Slice(items, func(i, j int) bool { return fmt.Sprint(items[i]) < fmt.Sprint(items[j]) })
+		}
+		values := make([]cty.Value, 0, len(items))
+		for _, item := range items {
+			v, err := toCty(elem, item)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values = append(values, v)
+		}
+		if len(values) == 0 {
+			return cty.ListValEmpty(cty.DynamicPseudoType), nil
+		}
+		return cty.ListVal(values), nil
+	case schema.TypeMap:
+		elem := elemSchema(sch)
+		m, _ := value.(map[string]interface{})
+		values := make(map[string]cty.Value, len(m))
+		for key, item := range m {
+			v, err := toCty(elem, item)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values[key] = v
+		}
+		if len(values) == 0 {
+			return cty.MapValEmpty(cty.String), nil
+		}
+		return cty.MapVal(values), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported type %s", sch.Type)
+	}
+}
+
+// elemSchema returns the schema of the elements of a list, set or map of primitives, which are strings unless set.
+func elemSchema(sch *schema.Schema) *schema.Schema {
+	if elem, ok := sch.Elem.(*schema.Schema); ok {
+		return elem
+	}
+	return &schema.Schema{Type: schema.TypeString}
+}
+
+func isJSONObject(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{") && json.Valid([]byte(s))
+}
+
+// jsonencode returns the tokens of a `jsonencode()` call, which is easier to read and edit than the JSON string.
+func jsonencode(s string) (hclwrite.Tokens, error) {
+	t, err := ctyjson.ImpliedType([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	v, err := ctyjson.Unmarshal([]byte(s), t)
+	if err != nil {
+		return nil, err
+	}
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(v)), nil
+}
+
+// uniqueLabel returns a valid resource label built from the name of the object, which isn't in labels yet.
+func uniqueLabel(name string, labels map[string]bool) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	label := b.String()
+	if label == "" || !(unicode.IsLetter(rune(label[0])) || label[0] == '_') {
+		label = "_" + label
+	}
+
+	unique := label
+	for i := 2; labels[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	labels[unique] = true
+	return unique
+}
@@ -3,11 +3,21 @@ package fleet
 import (
 	"context"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet/fleetapi"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
@@ -15,278 +25,378 @@ const (
 	monitorMetrics = "metrics"
 )
 
-func ResourceAgentPolicy() *schema.Resource {
-	agentPolicySchema := map[string]*schema.Schema{
-		"policy_id": {
-			Description: "Unique identifier of the agent policy.",
-			Type:        schema.TypeString,
-			Computed:    true,
-			Optional:    true,
-			ForceNew:    true,
-		},
-		"name": {
-			Description: "The name of the agent policy.",
-			Type:        schema.TypeString,
-			Required:    true,
-		},
-		"namespace": {
-			Description: "The namespace of the agent policy.",
-			Type:        schema.TypeString,
-			Required:    true,
-		},
-		"description": {
-			Description: "The description of the agent policy.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"data_output_id": {
-			Description: "The identifier for the data output.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"monitoring_output_id": {
-			Description: "The identifier for monitoring output.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"fleet_server_host_id": {
-			Description: "The identifier for the Fleet server host.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"download_source_id": {
-			Description: "The identifier for the Elastic Agent binary download server.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"sys_monitoring": {
-			Description: "Enable collection of system logs and metrics.",
-			Type:        schema.TypeBool,
-			Optional:    true,
-		},
-		"monitor_logs": {
-			Description: "Enable collection of agent logs.",
-			Type:        schema.TypeBool,
-			Optional:    true,
-		},
-		"monitor_metrics": {
-			Description: "Enable collection of agent metrics.",
-			Type:        schema.TypeBool,
-			Optional:    true,
+var (
+	_ resource.Resource                = &agentPolicyResource{}
+	_ resource.ResourceWithConfigure   = &agentPolicyResource{}
+	_ resource.ResourceWithImportState = &agentPolicyResource{}
+)
+
+// NewAgentPolicyResource is a helper function to simplify the provider implementation.
+func NewAgentPolicyResource() resource.Resource {
+	return &agentPolicyResource{}
+}
+
+type agentPolicyResource struct {
+	client *clients.ApiClient
+}
+
+type agentPolicyModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	PolicyID           types.String   `tfsdk:"policy_id"`
+	Name               types.String   `tfsdk:"name"`
+	Namespace          types.String   `tfsdk:"namespace"`
+	Description        types.String   `tfsdk:"description"`
+	DataOutputID       types.String   `tfsdk:"data_output_id"`
+	MonitoringOutputID types.String   `tfsdk:"monitoring_output_id"`
+	FleetServerHostID  types.String   `tfsdk:"fleet_server_host_id"`
+	DownloadSourceID   types.String   `tfsdk:"download_source_id"`
+	SysMonitoring      types.Bool     `tfsdk:"sys_monitoring"`
+	MonitorLogs        types.Bool     `tfsdk:"monitor_logs"`
+	MonitorMetrics     types.Bool     `tfsdk:"monitor_metrics"`
+	SkipDestroy        types.Bool     `tfsdk:"skip_destroy"`
+	FleetConnection    types.List     `tfsdk:"fleet_connection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *agentPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fleet_agent_policy"
+}
+
+func (r *agentPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a new Fleet Agent Policy. See https://www.elastic.co/guide/en/fleet/current/agent-policy.html",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the agent policy.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the agent policy.",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The namespace of the agent policy.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the agent policy.",
+				Optional:            true,
+			},
+			"data_output_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier for the data output.",
+				Optional:            true,
+			},
+			"monitoring_output_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier for monitoring output.",
+				Optional:            true,
+			},
+			"fleet_server_host_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier for the Fleet server host.",
+				Optional:            true,
+			},
+			"download_source_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier for the Elastic Agent binary download server.",
+				Optional:            true,
+			},
+			"sys_monitoring": schema.BoolAttribute{
+				MarkdownDescription: "Enable collection of system logs and metrics.",
+				Optional:            true,
+			},
+			"monitor_logs": schema.BoolAttribute{
+				MarkdownDescription: "Enable collection of agent logs.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"monitor_metrics": schema.BoolAttribute{
+				MarkdownDescription: "Enable collection of agent metrics.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"skip_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Set to true if you do not wish the agent policy to be deleted at destroy time, and instead just remove the agent policy from the Terraform state.",
+				Optional:            true,
+			},
 		},
-		"skip_destroy": {
-			Description: "Set to true if you do not wish the agent policy to be deleted at destroy time, and instead just remove the agent policy from the Terraform state.",
-			Type:        schema.TypeBool,
-			Optional:    true,
+		Blocks: map[string]schema.Block{
+			"fleet_connection": providerSchema.GetFleetFWResourceConnectionBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
+}
 
-	return &schema.Resource{
-		Description: "Creates a new Fleet Agent Policy. See https://www.elastic.co/guide/en/fleet/current/agent-policy.html",
+func (r *agentPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
 
-		CreateContext: resourceAgentPolicyCreate,
-		ReadContext:   resourceAgentPolicyRead,
-		UpdateContext: resourceAgentPolicyUpdate,
-		DeleteContext: resourceAgentPolicyDelete,
+	client, diags := clientFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.client = client
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+func (r *agentPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), req.ID)...)
+}
 
-		Schema: agentPolicySchema,
+func (r *agentPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan agentPolicyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-}
 
-func resourceAgentPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	req := fleetapi.AgentPolicyCreateRequest{
-		Name:      d.Get("name").(string),
-		Namespace: d.Get("namespace").(string),
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, plan.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if value := d.Get("policy_id").(string); value != "" {
-		req.Id = &value
+	body := fleetapi.AgentPolicyCreateRequest{
+		Name:               plan.Name.ValueString(),
+		Namespace:          plan.Namespace.ValueString(),
+		Description:        plan.Description.ValueStringPointer(),
+		DataOutputId:       plan.DataOutputID.ValueStringPointer(),
+		DownloadSourceId:   plan.DownloadSourceID.ValueStringPointer(),
+		FleetServerHostId:  plan.FleetServerHostID.ValueStringPointer(),
+		MonitoringOutputId: plan.MonitoringOutputID.ValueStringPointer(),
 	}
-	if value := d.Get("description").(string); value != "" {
-		req.Description = &value
+	if !plan.PolicyID.IsUnknown() && plan.PolicyID.ValueString() != "" {
+		body.Id = plan.PolicyID.ValueStringPointer()
 	}
-	if value := d.Get("data_output_id").(string); value != "" {
-		req.DataOutputId = &value
+	if monitoring := plan.monitoringEnabled(); len(monitoring) > 0 {
+		values := make([]fleetapi.AgentPolicyCreateRequestMonitoringEnabled, 0, len(monitoring))
+		for _, v := range monitoring {
+			values = append(values, fleetapi.AgentPolicyCreateRequestMonitoringEnabled(v))
+		}
+		body.MonitoringEnabled = &values
 	}
-	if value := d.Get("download_source_id").(string); value != "" {
-		req.DownloadSourceId = &value
+
+	policy, sdkDiags := fleet.CreateAgentPolicy(ctx, fleetClient, body)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("fleet_server_host_id").(string); value != "" {
-		req.FleetServerHostId = &value
+
+	plan.ID = types.StringValue(policy.Id)
+	plan.PolicyID = types.StringValue(policy.Id)
+	resp.Diagnostics.Append(r.read(ctx, fleetClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("monitoring_output_id").(string); value != "" {
-		req.MonitoringOutputId = &value
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *agentPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state agentPolicyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var monitoringValues []fleetapi.AgentPolicyCreateRequestMonitoringEnabled
-	if value := d.Get("monitor_logs").(bool); value {
-		monitoringValues = append(monitoringValues, monitorLogs)
+	timeout, diags := state.Timeouts.Read(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("monitor_metrics").(bool); value {
-		monitoringValues = append(monitoringValues, monitorMetrics)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, state.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if len(monitoringValues) > 0 {
-		req.MonitoringEnabled = &monitoringValues
+
+	// Resources created by the SDKv2 implementation, or imported, may only have their ID set.
+	if state.PolicyID.ValueString() == "" {
+		state.PolicyID = state.ID
 	}
 
-	policy, diags := fleet.CreateAgentPolicy(ctx, fleetClient, req)
-	if diags.HasError() {
-		return diags
+	policy, sdkDiags := fleet.ReadAgentPolicy(ctx, fleetClient, state.PolicyID.ValueString())
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(policy.Id)
-	if err := d.Set("policy_id", policy.Id); err != nil {
-		return diag.FromErr(err)
+	// Not found.
+	if policy == nil {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	return resourceAgentPolicyRead(ctx, d, meta)
+	state.populateFromAPI(policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func resourceAgentPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+func (r *agentPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan agentPolicyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	id := d.Get("policy_id").(string)
-	d.SetId(id)
-
-	req := fleetapi.AgentPolicyUpdateRequest{
-		Name:      d.Get("name").(string),
-		Namespace: d.Get("namespace").(string),
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	if value := d.Get("description").(string); value != "" {
-		req.Description = &value
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, plan.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("data_output_id").(string); value != "" {
-		req.DataOutputId = &value
+
+	body := fleetapi.AgentPolicyUpdateRequest{
+		Name:               plan.Name.ValueString(),
+		Namespace:          plan.Namespace.ValueString(),
+		Description:        plan.Description.ValueStringPointer(),
+		DataOutputId:       plan.DataOutputID.ValueStringPointer(),
+		DownloadSourceId:   plan.DownloadSourceID.ValueStringPointer(),
+		FleetServerHostId:  plan.FleetServerHostID.ValueStringPointer(),
+		MonitoringOutputId: plan.MonitoringOutputID.ValueStringPointer(),
 	}
-	if value := d.Get("download_source_id").(string); value != "" {
-		req.DownloadSourceId = &value
+	// Always send the monitoring settings so that disabling them is applied.
+	values := []fleetapi.AgentPolicyUpdateRequestMonitoringEnabled{}
+	for _, v := range plan.monitoringEnabled() {
+		values = append(values, fleetapi.AgentPolicyUpdateRequestMonitoringEnabled(v))
 	}
-	if value := d.Get("fleet_server_host_id").(string); value != "" {
-		req.FleetServerHostId = &value
+	body.MonitoringEnabled = &values
+
+	_, sdkDiags := fleet.UpdateAgentPolicy(ctx, fleetClient, plan.PolicyID.ValueString(), body)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("monitoring_output_id").(string); value != "" {
-		req.MonitoringOutputId = &value
+
+	resp.Diagnostics.Append(r.read(ctx, fleetClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var monitoringValues []fleetapi.AgentPolicyUpdateRequestMonitoringEnabled
-	if value := d.Get("monitor_logs").(bool); value {
-		monitoringValues = append(monitoringValues, monitorLogs)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *agentPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state agentPolicyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("monitor_metrics").(bool); value {
-		monitoringValues = append(monitoringValues, monitorMetrics)
+
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if len(monitoringValues) > 0 {
-		req.MonitoringEnabled = &monitoringValues
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if state.SkipDestroy.ValueBool() {
+		tflog.Debug(ctx, "Skipping destroy of Agent Policy", map[string]interface{}{"policy_id": state.PolicyID.ValueString()})
+		return
 	}
-	_, diags = fleet.UpdateAgentPolicy(ctx, fleetClient, id, req)
-	if diags.HasError() {
-		return diags
+
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, state.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return resourceAgentPolicyRead(ctx, d, meta)
+	sdkDiags := fleet.DeleteAgentPolicy(ctx, fleetClient, state.PolicyID.ValueString())
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
 }
 
-func resourceAgentPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
+// read refreshes the model from the API after a create or an update.
+func (r *agentPolicyResource) read(ctx context.Context, fleetClient *fleet.Client, model *agentPolicyModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	policy, sdkDiags := fleet.ReadAgentPolicy(ctx, fleetClient, model.PolicyID.ValueString())
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
 	if diags.HasError() {
 		return diags
 	}
-
-	id := d.Get("policy_id").(string)
-	d.SetId(id)
-
-	agentPolicy, diags := fleet.ReadAgentPolicy(ctx, fleetClient, id)
-	if diags.HasError() {
+	if policy == nil {
+		diags.AddError("Agent policy not found", "Agent policy ["+model.PolicyID.ValueString()+"] could not be read after being written")
 		return diags
 	}
 
-	// Not found.
-	if agentPolicy == nil {
-		d.SetId("")
-		return nil
-	}
+	model.populateFromAPI(policy)
+	return diags
+}
 
-	if err := d.Set("name", agentPolicy.Name); err != nil {
-		return diag.FromErr(err)
+func (model *agentPolicyModel) monitoringEnabled() []string {
+	var values []string
+	if model.MonitorLogs.ValueBool() {
+		values = append(values, monitorLogs)
 	}
-	if err := d.Set("namespace", agentPolicy.Namespace); err != nil {
-		return diag.FromErr(err)
+	if model.MonitorMetrics.ValueBool() {
+		values = append(values, monitorMetrics)
 	}
-	if agentPolicy.Description != nil {
-		if err := d.Set("description", *agentPolicy.Description); err != nil {
-			return diag.FromErr(err)
-		}
+	return values
+}
+
+func (model *agentPolicyModel) populateFromAPI(policy *fleetapi.AgentPolicy) {
+	model.ID = types.StringValue(policy.Id)
+	model.PolicyID = types.StringValue(policy.Id)
+	model.Name = types.StringValue(policy.Name)
+	model.Namespace = types.StringValue(policy.Namespace)
+	if policy.Description != nil {
+		model.Description = types.StringValue(*policy.Description)
 	}
-	if agentPolicy.DataOutputId != nil {
-		if err := d.Set("data_output_id", *agentPolicy.DataOutputId); err != nil {
-			return diag.FromErr(err)
-		}
+	if policy.DataOutputId != nil {
+		model.DataOutputID = types.StringValue(*policy.DataOutputId)
 	}
-	if agentPolicy.DownloadSourceId != nil {
-		if err := d.Set("download_source_id", *agentPolicy.DownloadSourceId); err != nil {
-			return diag.FromErr(err)
-		}
+	if policy.DownloadSourceId != nil {
+		model.DownloadSourceID = types.StringValue(*policy.DownloadSourceId)
 	}
-	if agentPolicy.FleetServerHostId != nil {
-		if err := d.Set("fleet_server_host_id", *agentPolicy.FleetServerHostId); err != nil {
-			return diag.FromErr(err)
-		}
+	if policy.FleetServerHostId != nil {
+		model.FleetServerHostID = types.StringValue(*policy.FleetServerHostId)
 	}
-	if agentPolicy.MonitoringOutputId != nil {
-		if err := d.Set("monitoring_output_id", *agentPolicy.MonitoringOutputId); err != nil {
-			return diag.FromErr(err)
-		}
+	if policy.MonitoringOutputId != nil {
+		model.MonitoringOutputID = types.StringValue(*policy.MonitoringOutputId)
 	}
-	if agentPolicy.MonitoringEnabled != nil {
-		for _, v := range *agentPolicy.MonitoringEnabled {
+
+	model.MonitorLogs = types.BoolValue(false)
+	model.MonitorMetrics = types.BoolValue(false)
+	if policy.MonitoringEnabled != nil {
+		for _, v := range *policy.MonitoringEnabled {
 			switch v {
 			case monitorLogs:
-				if err := d.Set("monitor_logs", true); err != nil {
-					return diag.FromErr(err)
-				}
+				model.MonitorLogs = types.BoolValue(true)
 			case monitorMetrics:
-				if err := d.Set("monitor_logs", true); err != nil {
-					return diag.FromErr(err)
-
-				}
+				model.MonitorMetrics = types.BoolValue(true)
 			}
 		}
 	}
-
-	return nil
-}
-
-func resourceAgentPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	if d.Get("skip_destroy").(bool) {
-		tflog.Debug(ctx, "Skipping destroy of Agent Policy", map[string]interface{}{"policy_id": d.Id()})
-		return nil
-	}
-
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
-	}
-
-	id := d.Get("policy_id").(string)
-	d.SetId(id)
-
-	if diags = fleet.DeleteAgentPolicy(ctx, fleetClient, id); diags.HasError() {
-		return diags
-	}
-	d.SetId("")
-
-	return diags
 }
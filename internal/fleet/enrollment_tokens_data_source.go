@@ -63,6 +63,8 @@ func DataSourceEnrollmentTokens() *schema.Resource {
 		},
 	}
 
+	utils.AddFleetConnectionSchema(enrollmentTokenSchema)
+
 	return &schema.Resource{
 		Description: "Retrieves Elasticsearch API keys used to enroll Elastic Agents in Fleet. See: https://www.elastic.co/guide/en/fleet/current/fleet-enrollment-tokens.html",
 
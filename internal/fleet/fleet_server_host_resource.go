@@ -3,176 +3,311 @@ package fleet
 import (
 	"context"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet/fleetapi"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func ResourceFleetServerHost() *schema.Resource {
-	fleetServerHostSchema := map[string]*schema.Schema{
-		"host_id": {
-			Description: "Unique identifier of the Fleet server host.",
-			Type:        schema.TypeString,
-			Computed:    true,
-			Optional:    true,
-		},
-		"name": {
-			Description: "The name of the Fleet server host.",
-			Type:        schema.TypeString,
-			Required:    true,
-		},
-		"hosts": {
-			Description: "A list of hosts.",
-			Type:        schema.TypeList,
-			Required:    true,
-			MinItems:    1,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
+var (
+	_ resource.Resource                = &fleetServerHostResource{}
+	_ resource.ResourceWithConfigure   = &fleetServerHostResource{}
+	_ resource.ResourceWithImportState = &fleetServerHostResource{}
+)
+
+// NewFleetServerHostResource is a helper function to simplify the provider implementation.
+func NewFleetServerHostResource() resource.Resource {
+	return &fleetServerHostResource{}
+}
+
+type fleetServerHostResource struct {
+	client *clients.ApiClient
+}
+
+type fleetServerHostModel struct {
+	ID              types.String   `tfsdk:"id"`
+	HostID          types.String   `tfsdk:"host_id"`
+	Name            types.String   `tfsdk:"name"`
+	Hosts           types.List     `tfsdk:"hosts"`
+	Default         types.Bool     `tfsdk:"default"`
+	FleetConnection types.List     `tfsdk:"fleet_connection"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *fleetServerHostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fleet_server_host"
+}
+
+func (r *fleetServerHostResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a new Fleet Server Host.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host_id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the Fleet server host.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Fleet server host.",
+				Required:            true,
+			},
+			"hosts": schema.ListAttribute{
+				MarkdownDescription: "A list of hosts.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"default": schema.BoolAttribute{
+				MarkdownDescription: "Set as default.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 		},
-		"default": {
-			Description: "Set as default.",
-			Type:        schema.TypeBool,
-			Optional:    true,
+		Blocks: map[string]schema.Block{
+			"fleet_connection": providerSchema.GetFleetFWResourceConnectionBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
+}
 
-	return &schema.Resource{
-		Description: "Creates a new Fleet Server Host.",
+func (r *fleetServerHostResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
 
-		CreateContext: resourceFleetServerHostCreate,
-		ReadContext:   resourceFleetServerHostRead,
-		UpdateContext: resourceFleetServerHostUpdate,
-		DeleteContext: resourceFleetServerHostDelete,
+	client, diags := clientFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.client = client
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+func (r *fleetServerHostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("host_id"), req.ID)...)
+}
 
-		Schema: fleetServerHostSchema,
+func (r *fleetServerHostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fleetServerHostModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-}
 
-func resourceFleetServerHostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	req := fleetapi.PostFleetServerHostsJSONRequestBody{
-		Name: d.Get("name").(string),
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, plan.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if value := d.Get("hosts").([]interface{}); len(value) > 0 {
-		for _, v := range value {
-			if vStr, ok := v.(string); ok && vStr != "" {
-				req.HostUrls = append(req.HostUrls, vStr)
-			}
-		}
+	body := fleetapi.PostFleetServerHostsJSONRequestBody{
+		Name: plan.Name.ValueString(),
+	}
+	resp.Diagnostics.Append(plan.Hosts.ElementsAs(ctx, &body.HostUrls, false)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("default").(bool); value {
-		req.IsDefault = &value
+	if !plan.HostID.IsUnknown() && plan.HostID.ValueString() != "" {
+		body.Id = plan.HostID.ValueStringPointer()
+	}
+	if plan.Default.ValueBool() {
+		body.IsDefault = plan.Default.ValueBoolPointer()
 	}
 
-	host, diags := fleet.CreateFleetServerHost(ctx, fleetClient, req)
-	if diags.HasError() {
-		return diags
+	host, sdkDiags := fleet.CreateFleetServerHost(ctx, fleetClient, body)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(host.Id)
-	if err := d.Set("host_id", host.Id); err != nil {
-		return diag.FromErr(err)
+	plan.ID = types.StringValue(host.Id)
+	plan.HostID = types.StringValue(host.Id)
+	resp.Diagnostics.Append(r.read(ctx, fleetClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return resourceFleetServerHostRead(ctx, d, meta)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceFleetServerHostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+func (r *fleetServerHostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fleetServerHostModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	id := d.Get("host_id").(string)
-	d.SetId(id)
-
-	req := fleetapi.UpdateFleetServerHostsJSONRequestBody{}
+	timeout, diags := state.Timeouts.Read(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	if value, ok := d.Get("name").(string); ok && value != "" {
-		req.Name = &value
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, state.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	var hosts []string
-	if value, ok := d.Get("hosts").([]interface{}); ok && len(value) > 0 {
-		for _, v := range value {
-			if vStr, ok := v.(string); ok && vStr != "" {
-				hosts = append(hosts, vStr)
-			}
-		}
+
+	// Resources created by the SDKv2 implementation, or imported, may only have their ID set.
+	if state.HostID.ValueString() == "" {
+		state.HostID = state.ID
 	}
-	if hosts != nil {
-		req.HostUrls = &hosts
+
+	host, sdkDiags := fleet.ReadFleetServerHost(ctx, fleetClient, state.HostID.ValueString())
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("default").(bool); value {
-		req.IsDefault = &value
+
+	// Not found.
+	if host == nil {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	_, diags = fleet.UpdateFleetServerHost(ctx, fleetClient, id, req)
-	if diags.HasError() {
-		return diags
+	resp.Diagnostics.Append(state.populateFromAPI(ctx, host)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return resourceFleetServerHostRead(ctx, d, meta)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func resourceFleetServerHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+func (r *fleetServerHostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan fleetServerHostModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	id := d.Get("host_id").(string)
-	d.SetId(id)
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	host, diags := fleet.ReadFleetServerHost(ctx, fleetClient, id)
-	if diags.HasError() {
-		return diags
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, plan.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Not found.
-	if host == nil {
-		d.SetId("")
-		return nil
+	body := fleetapi.UpdateFleetServerHostsJSONRequestBody{
+		Name:      plan.Name.ValueStringPointer(),
+		IsDefault: plan.Default.ValueBoolPointer(),
+	}
+	var hosts []string
+	resp.Diagnostics.Append(plan.Hosts.ElementsAs(ctx, &hosts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	body.HostUrls = &hosts
 
-	if host.Name != nil {
-		if err := d.Set("name", *host.Name); err != nil {
-			return diag.FromErr(err)
-		}
+	_, sdkDiags := fleet.UpdateFleetServerHost(ctx, fleetClient, plan.HostID.ValueString(), body)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, fleetClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fleetServerHostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fleetServerHostModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err := d.Set("hosts", host.HostUrls); err != nil {
-		return diag.FromErr(err)
+
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err := d.Set("default", host.IsDefault); err != nil {
-		return diag.FromErr(err)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, state.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return nil
+	sdkDiags := fleet.DeleteFleetServerHost(ctx, fleetClient, state.HostID.ValueString())
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
 }
 
-func resourceFleetServerHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
+// read refreshes the model from the API after a create or an update.
+func (r *fleetServerHostResource) read(ctx context.Context, fleetClient *fleet.Client, model *fleetServerHostModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	host, sdkDiags := fleet.ReadFleetServerHost(ctx, fleetClient, model.HostID.ValueString())
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
 	if diags.HasError() {
 		return diags
 	}
-
-	id := d.Get("host_id").(string)
-	d.SetId(id)
-
-	if diags = fleet.DeleteFleetServerHost(ctx, fleetClient, id); diags.HasError() {
+	if host == nil {
+		diags.AddError("Fleet server host not found", "Fleet server host ["+model.HostID.ValueString()+"] could not be read after being written")
 		return diags
 	}
-	d.SetId("")
 
+	return model.populateFromAPI(ctx, host)
+}
+
+func (model *fleetServerHostModel) populateFromAPI(ctx context.Context, host *fleetapi.FleetServerHost) diag.Diagnostics {
+	var diags diag.Diagnostics
+	model.ID = types.StringValue(host.Id)
+	model.HostID = types.StringValue(host.Id)
+	if host.Name != nil {
+		model.Name = types.StringValue(*host.Name)
+	}
+	model.Hosts, diags = types.ListValueFrom(ctx, types.StringType, host.HostUrls)
+	model.Default = types.BoolValue(host.IsDefault)
 	return diags
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
@@ -18,6 +19,7 @@ import (
 var minVersionFleetServerHost = version.Must(version.NewVersion("8.6.0"))
 
 func TestAccResourceFleetServerHost(t *testing.T) {
+	cassette.Start(t)
 	policyName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
@@ -47,6 +49,38 @@ func TestAccResourceFleetServerHost(t *testing.T) {
 	})
 }
 
+func TestAccResourceFleetServerHostFromSDK(t *testing.T) {
+	cassette.Start(t)
+	policyName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		CheckDestroy: checkResourceFleetServerHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				// Create the resource with the last provider version where it was implemented with the SDKv2.
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"elasticstack": {
+						Source:            "elastic/elasticstack",
+						VersionConstraint: "0.7.0",
+					},
+				},
+				SkipFunc: versionutils.CheckIfVersionIsUnsupported(minVersionFleetServerHost),
+				Config:   testAccResourceFleetServerHostCreate(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("elasticstack_fleet_server_host.test_host", "host_id"),
+				),
+			},
+			{
+				ProtoV5ProviderFactories: acctest.Providers,
+				SkipFunc:                 versionutils.CheckIfVersionIsUnsupported(minVersionFleetServerHost),
+				Config:                   testAccResourceFleetServerHostCreate(policyName),
+				PlanOnly:                 true,
+			},
+		},
+	})
+}
+
 func testAccResourceFleetServerHostCreate(id string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
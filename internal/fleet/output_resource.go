@@ -3,239 +3,370 @@ package fleet
 import (
 	"context"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet/fleetapi"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func ResourceOutput() *schema.Resource {
-	outputSchema := map[string]*schema.Schema{
-		"output_id": {
-			Description: "Unique identifier of the output.",
-			Type:        schema.TypeString,
-			Computed:    true,
-			Optional:    true,
-		},
-		"name": {
-			Description: "The name of the output.",
-			Type:        schema.TypeString,
-			Required:    true,
-		},
-		"type": {
-			Description:  "The output type.",
-			Type:         schema.TypeString,
-			Required:     true,
-			ValidateFunc: validation.StringInSlice([]string{"elasticsearch", "logstash"}, false),
-		},
-		"hosts": {
-			Description: "A list of hosts.",
-			Type:        schema.TypeList,
-			Optional:    true,
-			MinItems:    1,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
+var (
+	_ resource.Resource                = &outputResource{}
+	_ resource.ResourceWithConfigure   = &outputResource{}
+	_ resource.ResourceWithImportState = &outputResource{}
+)
+
+// NewOutputResource is a helper function to simplify the provider implementation.
+func NewOutputResource() resource.Resource {
+	return &outputResource{}
+}
+
+type outputResource struct {
+	client *clients.ApiClient
+}
+
+type outputModel struct {
+	ID                  types.String   `tfsdk:"id"`
+	OutputID            types.String   `tfsdk:"output_id"`
+	Name                types.String   `tfsdk:"name"`
+	Type                types.String   `tfsdk:"type"`
+	Hosts               types.List     `tfsdk:"hosts"`
+	CaSha256            types.String   `tfsdk:"ca_sha256"`
+	DefaultIntegrations types.Bool     `tfsdk:"default_integrations"`
+	DefaultMonitoring   types.Bool     `tfsdk:"default_monitoring"`
+	ConfigYaml          types.String   `tfsdk:"config_yaml"`
+	FleetConnection     types.List     `tfsdk:"fleet_connection"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *outputResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fleet_output"
+}
+
+func (r *outputResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a new Fleet Output.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"output_id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the output.",
+				Computed:            true,
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the output.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The output type.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("elasticsearch", "logstash"),
+				},
+			},
+			"hosts": schema.ListAttribute{
+				MarkdownDescription: "A list of hosts.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"ca_sha256": schema.StringAttribute{
+				MarkdownDescription: "Fingerprint of the Elasticsearch CA certificate.",
+				Optional:            true,
+			},
+			"default_integrations": schema.BoolAttribute{
+				MarkdownDescription: "Make this output the default for agent integrations.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"default_monitoring": schema.BoolAttribute{
+				MarkdownDescription: "Make this output the default for agent monitoring.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"config_yaml": schema.StringAttribute{
+				MarkdownDescription: "Advanced YAML configuration. YAML settings here will be added to the output section of each agent policy.",
+				Optional:            true,
+				Sensitive:           true,
 			},
 		},
-		"ca_sha256": {
-			Description: "Fingerprint of the Elasticsearch CA certificate.",
-			Type:        schema.TypeString,
-			Optional:    true,
-		},
-		"default_integrations": {
-			Description: "Make this output the default for agent integrations.",
-			Type:        schema.TypeBool,
-			Optional:    true,
-		},
-		"default_monitoring": {
-			Description: "Make this output the default for agent monitoring.",
-			Type:        schema.TypeBool,
-			Optional:    true,
-		},
-		"config_yaml": {
-			Description: "Advanced YAML configuration. YAML settings here will be added to the output section of each agent policy.",
-			Type:        schema.TypeString,
-			Optional:    true,
-			Sensitive:   true,
+		Blocks: map[string]schema.Block{
+			"fleet_connection": providerSchema.GetFleetFWResourceConnectionBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
+}
 
-	return &schema.Resource{
-		Description: "Creates a new Fleet Output.",
-
-		CreateContext: resourceOutputCreate,
-		ReadContext:   resourceOutputRead,
-		UpdateContext: resourceOutputUpdate,
-		DeleteContext: resourceOutputDelete,
+func (r *outputResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+	client, diags := clientFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.client = client
+}
 
-		Schema: outputSchema,
-	}
+func (r *outputResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_id"), req.ID)...)
 }
 
-func resourceOutputCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+func (r *outputResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan outputModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	req := fleetapi.PostOutputsJSONRequestBody{
-		Name: d.Get("name").(string),
-		Type: fleetapi.PostOutputsJSONBodyType(d.Get("type").(string)),
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	var hosts []string
-	if value := d.Get("hosts").([]interface{}); len(value) > 0 {
-		for _, v := range value {
-			if vStr, ok := v.(string); ok && vStr != "" {
-				hosts = append(hosts, vStr)
-			}
-		}
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, plan.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if hosts != nil {
-		req.Hosts = &hosts
+
+	body := fleetapi.PostOutputsJSONRequestBody{
+		Name:       plan.Name.ValueString(),
+		Type:       fleetapi.PostOutputsJSONBodyType(plan.Type.ValueString()),
+		CaSha256:   plan.CaSha256.ValueStringPointer(),
+		ConfigYaml: plan.ConfigYaml.ValueStringPointer(),
 	}
-	if value := d.Get("default_integrations").(bool); value {
-		req.IsDefault = &value
+	if !plan.OutputID.IsUnknown() && plan.OutputID.ValueString() != "" {
+		body.Id = plan.OutputID.ValueStringPointer()
 	}
-	if value := d.Get("default_monitoring").(bool); value {
-		req.IsDefaultMonitoring = &value
+	if plan.DefaultIntegrations.ValueBool() {
+		body.IsDefault = plan.DefaultIntegrations.ValueBoolPointer()
 	}
-	if value, ok := d.Get("ca_sha256").(string); ok && value != "" {
-		req.CaSha256 = &value
+	if plan.DefaultMonitoring.ValueBool() {
+		body.IsDefaultMonitoring = plan.DefaultMonitoring.ValueBoolPointer()
 	}
-	if value, ok := d.Get("config_yaml").(string); ok && value != "" {
-		req.ConfigYaml = &value
+	hosts, diags := plan.hosts(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	body.Hosts = hosts
 
-	host, diags := fleet.CreateOutput(ctx, fleetClient, req)
-	if diags.HasError() {
-		return diags
+	output, sdkDiags := fleet.CreateOutput(ctx, fleetClient, body)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(host.Id)
-	if err := d.Set("output_id", host.Id); err != nil {
-		return diag.FromErr(err)
+	plan.ID = types.StringValue(output.Id)
+	plan.OutputID = types.StringValue(output.Id)
+	resp.Diagnostics.Append(r.read(ctx, fleetClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return resourceOutputRead(ctx, d, meta)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceOutputUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+func (r *outputResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state outputModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	id := d.Get("output_id").(string)
-	d.SetId(id)
+	timeout, diags := state.Timeouts.Read(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	req := fleetapi.UpdateOutputJSONRequestBody{
-		Name: d.Get("name").(string),
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, state.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var hosts []string
-	if value := d.Get("hosts").([]interface{}); len(value) > 0 {
-		for _, v := range value {
-			if vStr, ok := v.(string); ok && vStr != "" {
-				hosts = append(hosts, vStr)
-			}
-		}
+	// Resources created by the SDKv2 implementation, or imported, may only have their ID set.
+	if state.OutputID.ValueString() == "" {
+		state.OutputID = state.ID
 	}
-	if hosts != nil {
-		req.Hosts = &hosts
+
+	output, sdkDiags := fleet.ReadOutput(ctx, fleetClient, state.OutputID.ValueString())
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value := d.Get("default_integrations").(bool); value {
-		req.IsDefault = &value
+
+	// Not found.
+	if output == nil {
+		resp.State.RemoveResource(ctx)
+		return
 	}
-	if value := d.Get("default_monitoring").(bool); value {
-		req.IsDefaultMonitoring = &value
+
+	resp.Diagnostics.Append(state.populateFromAPI(ctx, output)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value, ok := d.Get("ca_sha256").(string); ok && value != "" {
-		req.CaSha256 = &value
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *outputResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan outputModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value, ok := d.Get("config_yaml").(string); ok && value != "" {
-		req.ConfigYaml = &value
+
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if value, ok := d.Get("type").(string); ok && value != "" {
-		req.Type = fleetapi.UpdateOutputJSONBodyType(value)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, plan.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	_, diags = fleet.UpdateOutput(ctx, fleetClient, id, req)
-	if diags.HasError() {
-		return diags
+	body := fleetapi.UpdateOutputJSONRequestBody{
+		Name:                plan.Name.ValueString(),
+		Type:                fleetapi.UpdateOutputJSONBodyType(plan.Type.ValueString()),
+		CaSha256:            plan.CaSha256.ValueStringPointer(),
+		ConfigYaml:          plan.ConfigYaml.ValueStringPointer(),
+		IsDefault:           plan.DefaultIntegrations.ValueBoolPointer(),
+		IsDefaultMonitoring: plan.DefaultMonitoring.ValueBoolPointer(),
+	}
+	hosts, diags := plan.hosts(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body.Hosts = hosts
+
+	_, sdkDiags := fleet.UpdateOutput(ctx, fleetClient, plan.OutputID.ValueString(), body)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return resourceOutputRead(ctx, d, meta)
+	resp.Diagnostics.Append(r.read(ctx, fleetClient, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceOutputRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+func (r *outputResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state outputModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	id := d.Get("output_id").(string)
-	d.SetId(id)
+	fleetClient, diags := getFleetClientFromFramework(ctx, r.client, state.FleetConnection)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	output, diags := fleet.ReadOutput(ctx, fleetClient, id)
+	sdkDiags := fleet.DeleteOutput(ctx, fleetClient, state.OutputID.ValueString())
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+}
+
+// read refreshes the model from the API after a create or an update.
+func (r *outputResource) read(ctx context.Context, fleetClient *fleet.Client, model *outputModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	output, sdkDiags := fleet.ReadOutput(ctx, fleetClient, model.OutputID.ValueString())
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
 	if diags.HasError() {
 		return diags
 	}
-
-	// Not found.
 	if output == nil {
-		d.SetId("")
-		return nil
+		diags.AddError("Output not found", "Output ["+model.OutputID.ValueString()+"] could not be read after being written")
+		return diags
 	}
 
-	if err := d.Set("name", output.Name); err != nil {
-		return diag.FromErr(err)
+	return model.populateFromAPI(ctx, output)
+}
+
+func (model *outputModel) hosts(ctx context.Context) (*[]string, diag.Diagnostics) {
+	if model.Hosts.IsNull() || model.Hosts.IsUnknown() {
+		return nil, nil
 	}
+
+	var hosts []string
+	diags := model.Hosts.ElementsAs(ctx, &hosts, false)
+	return &hosts, diags
+}
+
+func (model *outputModel) populateFromAPI(ctx context.Context, output *fleetapi.Output) diag.Diagnostics {
+	var diags diag.Diagnostics
+	model.ID = types.StringValue(output.Id)
+	model.OutputID = types.StringValue(output.Id)
+	model.Name = types.StringValue(output.Name)
+	model.Type = types.StringValue(string(output.Type))
 	if output.Hosts != nil {
-		if err := d.Set("hosts", *output.Hosts); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-	if err := d.Set("default_integrations", output.IsDefault); err != nil {
-		return diag.FromErr(err)
+		model.Hosts, diags = types.ListValueFrom(ctx, types.StringType, *output.Hosts)
 	}
+	model.DefaultIntegrations = types.BoolValue(output.IsDefault)
 	if output.IsDefaultMonitoring != nil {
-		if err := d.Set("default_monitoring", *output.IsDefaultMonitoring); err != nil {
-			return diag.FromErr(err)
-		}
+		model.DefaultMonitoring = types.BoolValue(*output.IsDefaultMonitoring)
 	}
 	if output.CaSha256 != nil {
-		if err := d.Set("ca_sha256", *output.CaSha256); err != nil {
-			return diag.FromErr(err)
-		}
+		model.CaSha256 = types.StringValue(*output.CaSha256)
 	}
 	if output.ConfigYaml != nil {
-		if err := d.Set("config_yaml", *output.ConfigYaml); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	return nil
-}
-
-func resourceOutputDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fleetClient, diags := getFleetClient(d, meta)
-	if diags.HasError() {
-		return diags
+		model.ConfigYaml = types.StringValue(*output.ConfigYaml)
 	}
-
-	id := d.Get("output_id").(string)
-	d.SetId(id)
-
-	if diags = fleet.DeleteOutput(ctx, fleetClient, id); diags.HasError() {
-		return diags
-	}
-	d.SetId("")
-
 	return diags
 }
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
@@ -18,36 +20,84 @@ import (
 var minVersionOutput = version.Must(version.NewVersion("8.6.0"))
 
 func TestAccResourceOutput(t *testing.T) {
+	cassette.Start(t)
 	policyName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
 		CheckDestroy:             checkResourceOutputDestroy,
 		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceOutputSteps(policyName),
+	})
+}
+
+func TestResourceOutput(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceOutputDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceOutputSteps("output"),
+	})
+}
+
+func testAccResourceOutputSteps(policyName string) []resource.TestStep {
+	return []resource.TestStep{
+		{
+			SkipFunc: versionutils.CheckIfVersionIsUnsupported(minVersionOutput),
+			Config:   testAccResourceOutputCreate(policyName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "name", fmt.Sprintf("Output %s", policyName)),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "type", "elasticsearch"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "config_yaml", "\"ssl.verification_mode\": \"none\"\n"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_integrations", "false"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_monitoring", "false"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "hosts.0", "https://elasticsearch:9200"),
+			),
+		},
+		{
+			SkipFunc: versionutils.CheckIfVersionIsUnsupported(minVersionOutput),
+			Config:   testAccResourceOutputUpdate(policyName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "name", fmt.Sprintf("Updated Output %s", policyName)),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "type", "elasticsearch"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "config_yaml", "\"ssl.verification_mode\": \"none\"\n"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_integrations", "false"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_monitoring", "false"),
+				resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "hosts.0", "https://elasticsearch:9200"),
+			),
+		},
+	}
+}
+
+func TestAccResourceOutputFromSDK(t *testing.T) {
+	cassette.Start(t)
+	policyName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acctest.PreCheck(t) },
+		CheckDestroy: checkResourceOutputDestroy,
 		Steps: []resource.TestStep{
 			{
+				// Create the resource with the last provider version where it was implemented with the SDKv2.
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"elasticstack": {
+						Source:            "elastic/elasticstack",
+						VersionConstraint: "0.7.0",
+					},
+				},
 				SkipFunc: versionutils.CheckIfVersionIsUnsupported(minVersionOutput),
 				Config:   testAccResourceOutputCreate(policyName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "name", fmt.Sprintf("Output %s", policyName)),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "type", "elasticsearch"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "config_yaml", "\"ssl.verification_mode\": \"none\"\n"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_integrations", "false"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_monitoring", "false"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "hosts.0", "https://elasticsearch:9200"),
+					resource.TestCheckResourceAttrSet("elasticstack_fleet_output.test_output", "output_id"),
 				),
 			},
 			{
-				SkipFunc: versionutils.CheckIfVersionIsUnsupported(minVersionOutput),
-				Config:   testAccResourceOutputUpdate(policyName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "name", fmt.Sprintf("Updated Output %s", policyName)),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "type", "elasticsearch"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "config_yaml", "\"ssl.verification_mode\": \"none\"\n"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_integrations", "false"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "default_monitoring", "false"),
-					resource.TestCheckResourceAttr("elasticstack_fleet_output.test_output", "hosts.0", "https://elasticsearch:9200"),
-				),
+				ProtoV5ProviderFactories: acctest.Providers,
+				SkipFunc:                 versionutils.CheckIfVersionIsUnsupported(minVersionOutput),
+				Config:                   testAccResourceOutputCreate(policyName),
+				PlanOnly:                 true,
 			},
 		},
 	})
@@ -1,8 +1,14 @@
 package fleet
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/fleet"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -19,3 +25,34 @@ func getFleetClient(d *schema.ResourceData, meta interface{}) (*fleet.Client, di
 
 	return fleetClient, nil
 }
+
+// clientFromProviderData extracts the API client configured by the plugin framework provider.
+func clientFromProviderData(providerData any) (*clients.ApiClient, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	client, ok := providerData.(*clients.ApiClient)
+	if !ok {
+		diags.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *clients.ApiClient, got: %T. Please report this issue to the provider developers.", providerData),
+		)
+		return nil, diags
+	}
+	return client, diags
+}
+
+// getFleetClientFromFramework returns the Fleet client from the API client configured by the plugin framework provider,
+// or from the resource fleet_connection block when it is defined.
+func getFleetClientFromFramework(ctx context.Context, client *clients.ApiClient, fleetConnection types.List) (*fleet.Client, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	resourceClient, sdkDiags := clients.NewApiClientFromFrameworkResource(ctx, fleetConnection, client)
+	diags.Append(utils.FrameworkDiagsFromSDK(sdkDiags)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	fleetClient, err := resourceClient.GetFleetClient()
+	if err != nil {
+		diags.AddError("Unable to get Fleet client", err.Error())
+		return nil, diags
+	}
+	return fleetClient, diags
+}
@@ -130,7 +130,9 @@ func ResourceAlertingRule() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
+	utils.AddKibanaConnectionSchema(apikeySchema)
+
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Creates a Kibana rule. See https://www.elastic.co/guide/en/kibana/master/create-rule-api.html",
 
 		CreateContext: resourceRuleCreate,
@@ -143,7 +145,7 @@ func ResourceAlertingRule() *schema.Resource {
 		},
 
 		Schema: apikeySchema,
-	}
+	})
 }
 
 func getAlertingRuleFromResourceData(d *schema.ResourceData) (models.AlertingRule, diag.Diagnostics) {
@@ -70,7 +70,9 @@ func ResourceActionConnector() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
+	utils.AddKibanaConnectionSchema(apikeySchema)
+
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Creates a Kibana action connector. See https://www.elastic.co/guide/en/kibana/current/action-types.html",
 
 		CreateContext: resourceConnectorCreate,
@@ -84,7 +86,7 @@ func ResourceActionConnector() *schema.Resource {
 		},
 
 		Schema: apikeySchema,
-	}
+	})
 }
 
 func connectorCustomizeDiff(ctx context.Context, rd *schema.ResourceDiff, in interface{}) error {
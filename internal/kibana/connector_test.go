@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients/kibana"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/hashicorp/go-version"
@@ -19,6 +21,7 @@ import (
 func TestAccResourceKibanaConnectorCasesWebhook(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("8.4.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -131,6 +134,7 @@ func TestAccResourceKibanaConnectorCasesWebhook(t *testing.T) {
 func TestAccResourceKibanaConnectorEmail(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -211,10 +215,31 @@ func TestAccResourceKibanaConnectorEmail(t *testing.T) {
 }
 
 func TestAccResourceKibanaConnectorIndex(t *testing.T) {
-	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
-
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		CheckDestroy:             checkResourceKibanaConnectorDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceKibanaConnectorIndexSteps(connectorName),
+	})
+}
+
+func TestResourceKibanaConnectorIndex(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceKibanaConnectorDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceKibanaConnectorIndexSteps("connector"),
+	})
+}
+
+func testAccResourceKibanaConnectorIndexSteps(connectorName string) []resource.TestStep {
+	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
+
 	create := func(name string) string {
 		return fmt.Sprintf(`
 	provider "elasticstack" {
@@ -251,38 +276,34 @@ func TestAccResourceKibanaConnectorIndex(t *testing.T) {
 			name)
 	}
 
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { acctest.PreCheck(t) },
-		CheckDestroy:             checkResourceKibanaConnectorDestroy,
-		ProtoV5ProviderFactories: acctest.Providers,
-		Steps: []resource.TestStep{
-			{
-				SkipFunc: versionutils.CheckIfVersionIsUnsupported(minSupportedVersion),
-				Config:   create(connectorName),
-				Check: resource.ComposeTestCheckFunc(
-					testCommonAttributes(connectorName, ".index"),
-
-					resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"index\":\"\.kibana\"`)),
-					resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"refresh\":true`)),
-				),
-			},
-			{
-				SkipFunc: versionutils.CheckIfVersionIsUnsupported(minSupportedVersion),
-				Config:   update(connectorName),
-				Check: resource.ComposeTestCheckFunc(
-					testCommonAttributes(fmt.Sprintf("Updated %s", connectorName), ".index"),
+	return []resource.TestStep{
+		{
+			SkipFunc: versionutils.CheckIfVersionIsUnsupported(minSupportedVersion),
+			Config:   create(connectorName),
+			Check: resource.ComposeTestCheckFunc(
+				testCommonAttributes(connectorName, ".index"),
 
-					resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"index\":\"\.kibana\"`)),
-					resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"refresh\":false`)),
-				),
-			},
+				resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"index\":\"\.kibana\"`)),
+				resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"refresh\":true`)),
+			),
 		},
-	})
+		{
+			SkipFunc: versionutils.CheckIfVersionIsUnsupported(minSupportedVersion),
+			Config:   update(connectorName),
+			Check: resource.ComposeTestCheckFunc(
+				testCommonAttributes(fmt.Sprintf("Updated %s", connectorName), ".index"),
+
+				resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"index\":\"\.kibana\"`)),
+				resource.TestMatchResourceAttr("elasticstack_kibana_action_connector.test", "config", regexp.MustCompile(`\"refresh\":false`)),
+			),
+		},
+	}
 }
 
 func TestAccResourceKibanaConnectorJira(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -367,6 +388,7 @@ func TestAccResourceKibanaConnectorJira(t *testing.T) {
 func TestAccResourceKibanaConnectorOpsgenie(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("8.6.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -443,6 +465,7 @@ func TestAccResourceKibanaConnectorOpsgenie(t *testing.T) {
 func TestAccResourceKibanaConnectorPagerduty(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -517,6 +540,7 @@ func TestAccResourceKibanaConnectorPagerduty(t *testing.T) {
 func TestAccResourceKibanaConnectorResilient(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -601,6 +625,7 @@ func TestAccResourceKibanaConnectorResilient(t *testing.T) {
 func TestAccResourceKibanaConnectorServerLog(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -657,6 +682,7 @@ func TestAccResourceKibanaConnectorServerLog(t *testing.T) {
 func TestAccResourceKibanaConnectorServicenow(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -744,6 +770,7 @@ func TestAccResourceKibanaConnectorServicenow(t *testing.T) {
 func TestAccResourceKibanaConnectorServicenowItom(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("8.3.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -824,6 +851,7 @@ func TestAccResourceKibanaConnectorServicenowItom(t *testing.T) {
 func TestAccResourceKibanaConnectorServicenowSir(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -910,6 +938,7 @@ func TestAccResourceKibanaConnectorServicenowSir(t *testing.T) {
 func TestAccResourceKibanaConnectorSlack(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -976,6 +1005,7 @@ func TestAccResourceKibanaConnectorSlack(t *testing.T) {
 func TestAccResourceKibanaConnectorSwimlane(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -1084,6 +1114,7 @@ func TestAccResourceKibanaConnectorSwimlane(t *testing.T) {
 func TestAccResourceKibanaConnectorTeams(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -1150,6 +1181,7 @@ func TestAccResourceKibanaConnectorTeams(t *testing.T) {
 func TestAccResourceKibanaConnectorTines(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("8.6.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -1230,6 +1262,7 @@ func TestAccResourceKibanaConnectorTines(t *testing.T) {
 func TestAccResourceKibanaConnectorWebhook(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("7.14.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -1298,6 +1331,7 @@ func TestAccResourceKibanaConnectorWebhook(t *testing.T) {
 func TestAccResourceKibanaConnectorXmatters(t *testing.T) {
 	minSupportedVersion := version.Must(version.NewSemver("8.2.0"))
 
+	cassette.Start(t)
 	connectorName := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	create := func(name string) string {
@@ -415,7 +415,9 @@ func ResourceSlo() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
+	utils.AddKibanaConnectionSchema(sloSchema)
+
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Creates an SLO.",
 
 		CreateContext: resourceSloCreate,
@@ -428,7 +430,7 @@ func ResourceSlo() *schema.Resource {
 		},
 
 		Schema: sloSchema,
-	}
+	})
 }
 
 func getOrNilString(path string, d *schema.ResourceData) *string {
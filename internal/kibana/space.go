@@ -5,6 +5,7 @@ import (
 
 	"github.com/disaster37/go-kibana-rest/v8/kbapi"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -54,7 +55,9 @@ func ResourceSpace() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
+	utils.AddKibanaConnectionSchema(apikeySchema)
+
+	return utils.WithTimeouts(&schema.Resource{
 		Description: "Creates a Kibana space. See, https://www.elastic.co/guide/en/kibana/master/spaces-api-post.html",
 
 		CreateContext: resourceSpaceUpsert,
@@ -67,7 +70,7 @@ func ResourceSpace() *schema.Resource {
 		},
 
 		Schema: apikeySchema,
-	}
+	})
 }
 
 func resourceSpaceUpsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -76,7 +79,7 @@ func resourceSpaceUpsert(ctx context.Context, d *schema.ResourceData, meta inter
 		return diags
 	}
 
-	kibana, err := client.GetKibanaClient()
+	kibana, err := client.GetKibanaClientWithContext(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -142,7 +145,7 @@ func resourceSpaceRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	id := compId.ResourceId
 
-	kibana, err := client.GetKibanaClient()
+	kibana, err := client.GetKibanaClientWithContext(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -189,7 +192,7 @@ func resourceSpaceDelete(ctx context.Context, d *schema.ResourceData, meta inter
 		return diags
 	}
 
-	kibana, err := client.GetKibanaClient()
+	kibana, err := client.GetKibanaClientWithContext(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -5,42 +5,60 @@ import (
 	"testing"
 
 	"github.com/elastic/terraform-provider-elasticstack/internal/acctest"
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
 	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients/cassette"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceSpace(t *testing.T) {
+	cassette.Start(t)
 	spaceId := sdkacctest.RandStringFromCharSet(22, sdkacctest.CharSetAlphaNum)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
 		CheckDestroy:             checkResourceSpaceDestroy,
 		ProtoV5ProviderFactories: acctest.Providers,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceSpaceCreate(spaceId),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "space_id", spaceId),
-					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "name", fmt.Sprintf("Name %s", spaceId)),
-					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "description", "Test Space"),
-				),
-			},
-			{
-				Config: testAccResourceSpaceUpdate(spaceId),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "space_id", spaceId),
-					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "name", fmt.Sprintf("Updated %s", spaceId)),
-					resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "description", "Updated space description"),
-					resource.TestCheckTypeSetElemAttr("elasticstack_kibana_space.test_space", "disabled_features.*", "ingestManager"),
-					resource.TestCheckTypeSetElemAttr("elasticstack_kibana_space.test_space", "disabled_features.*", "enterpriseSearch"),
-				),
-			},
-		},
+		Steps:                    testAccResourceSpaceSteps(spaceId),
+	})
+}
+
+func TestResourceSpace(t *testing.T) {
+	fake.NewServer(t).Setenv(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheckTerraform(t) },
+		CheckDestroy:             checkResourceSpaceDestroy,
+		ProtoV5ProviderFactories: acctest.Providers,
+		Steps:                    testAccResourceSpaceSteps("space"),
 	})
 }
 
+func testAccResourceSpaceSteps(spaceId string) []resource.TestStep {
+	return []resource.TestStep{
+		{
+			Config: testAccResourceSpaceCreate(spaceId),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "space_id", spaceId),
+				resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "name", fmt.Sprintf("Name %s", spaceId)),
+				resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "description", "Test Space"),
+			),
+		},
+		{
+			Config: testAccResourceSpaceUpdate(spaceId),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "space_id", spaceId),
+				resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "name", fmt.Sprintf("Updated %s", spaceId)),
+				resource.TestCheckResourceAttr("elasticstack_kibana_space.test_space", "description", "Updated space description"),
+				resource.TestCheckTypeSetElemAttr("elasticstack_kibana_space.test_space", "disabled_features.*", "ingestManager"),
+				resource.TestCheckTypeSetElemAttr("elasticstack_kibana_space.test_space", "disabled_features.*", "enterpriseSearch"),
+			),
+		},
+	}
+}
+
 func testAccResourceSpaceCreate(id string) string {
 	return fmt.Sprintf(`
 provider "elasticstack" {
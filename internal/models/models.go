@@ -75,11 +75,34 @@ type ApiKey struct {
 type ApiKeyResponse struct {
 	ApiKey
 	RolesDescriptors map[string]Role `json:"role_descriptors,omitempty"`
+	Creation         int64           `json:"creation,omitempty"`
 	Expiration       int64           `json:"expiration,omitempty"`
 	Id               string          `json:"id,omitempty"`
 	Key              string          `json:"api_key,omitempty"`
 	EncodedKey       string          `json:"encoded,omitempty"`
 	Invalidated      bool            `json:"invalidated,omitempty"`
+	// Type is `cross_cluster` for cross-cluster API keys, which are returned with their Access.
+	Type   string                    `json:"type,omitempty"`
+	Access *CrossClusterApiKeyAccess `json:"access,omitempty"`
+}
+
+type CrossClusterApiKey struct {
+	Name       string                   `json:"name,omitempty"`
+	Access     CrossClusterApiKeyAccess `json:"access"`
+	Expiration string                   `json:"expiration,omitempty"`
+	Metadata   map[string]interface{}   `json:"metadata,omitempty"`
+}
+
+type CrossClusterApiKeyAccess struct {
+	Search      []CrossClusterApiKeyAccessEntry `json:"search,omitempty"`
+	Replication []CrossClusterApiKeyAccessEntry `json:"replication,omitempty"`
+}
+
+type CrossClusterApiKeyAccessEntry struct {
+	Names                  []string       `json:"names"`
+	FieldSecurity          *FieldSecurity `json:"field_security,omitempty"`
+	Query                  *string        `json:"query,omitempty"`
+	AllowRestrictedIndices *bool          `json:"allow_restricted_indices,omitempty"`
 }
 
 type IndexPerms struct {
@@ -207,6 +230,17 @@ type Index struct {
 	Settings map[string]interface{} `json:"settings,omitempty"`
 }
 
+// CatIndex is an index listed by the cat indices API, with its statistics. The statistics of closed indices are
+// empty.
+type CatIndex struct {
+	Index     string `json:"index"`
+	UUID      string `json:"uuid"`
+	Health    string `json:"health"`
+	Status    string `json:"status"`
+	DocsCount string `json:"docs.count"`
+	StoreSize string `json:"store.size"`
+}
+
 type PutIndexParams struct {
 	WaitForActiveShards string
 	MasterTimeout       time.Duration
@@ -224,6 +258,26 @@ type IndexAlias struct {
 	SearchRouting string                 `json:"search_routing,omitempty"`
 }
 
+// AliasAction is an action of the update aliases API, which applies all its actions atomically. Only one of the
+// fields is set.
+type AliasAction struct {
+	Add         *AliasActionParams `json:"add,omitempty"`
+	Remove      *AliasActionParams `json:"remove,omitempty"`
+	RemoveIndex *AliasActionParams `json:"remove_index,omitempty"`
+}
+
+type AliasActionParams struct {
+	Index string `json:"index"`
+	Alias string `json:"alias,omitempty"`
+	IndexAlias
+}
+
+type Task struct {
+	Completed bool                   `json:"completed"`
+	Error     map[string]interface{} `json:"error,omitempty"`
+	Response  map[string]interface{} `json:"response,omitempty"`
+}
+
 type DataStream struct {
 	Name           string                 `json:"name"`
 	TimestampField TimestampField         `json:"timestamp_field"`
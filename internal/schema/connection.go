@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema.Schema {
@@ -15,6 +16,12 @@ func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema
 	certDataPath := makePathRef(keyName, "cert_data")
 	keyFilePath := makePathRef(keyName, "key_file")
 	keyDataPath := makePathRef(keyName, "key_data")
+	apiKeyPath := makePathRef(keyName, "api_key")
+	endpointsPath := makePathRef(keyName, "endpoints")
+	cloudIDPath := makePathRef(keyName, "cloud_id")
+	cloudAuthPath := makePathRef(keyName, "cloud_auth")
+	bearerTokenPath := makePathRef(keyName, "bearer_token")
+	esClientAuthenticationPath := makePathRef(keyName, "es_client_authentication")
 
 	usernameRequiredWithValidation := []string{passwordPath}
 	passwordRequiredWithValidation := []string{usernamePath}
@@ -40,19 +47,21 @@ func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"username": {
-					Description:  "Username to use for API authentication to Elasticsearch.",
-					Type:         schema.TypeString,
-					Optional:     true,
-					DefaultFunc:  withEnvDefault("ELASTICSEARCH_USERNAME", nil),
-					RequiredWith: usernameRequiredWithValidation,
+					Description:   "Username to use for API authentication to Elasticsearch.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("ELASTICSEARCH_USERNAME", nil),
+					RequiredWith:  usernameRequiredWithValidation,
+					ConflictsWith: []string{cloudAuthPath, bearerTokenPath},
 				},
 				"password": {
-					Description:  "Password to use for API authentication to Elasticsearch.",
-					Type:         schema.TypeString,
-					Optional:     true,
-					Sensitive:    true,
-					DefaultFunc:  withEnvDefault("ELASTICSEARCH_PASSWORD", nil),
-					RequiredWith: passwordRequiredWithValidation,
+					Description:   "Password to use for API authentication to Elasticsearch.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   withEnvDefault("ELASTICSEARCH_PASSWORD", nil),
+					RequiredWith:  passwordRequiredWithValidation,
+					ConflictsWith: []string{cloudAuthPath, bearerTokenPath},
 				},
 				"api_key": {
 					Description:   "API Key to use for authentication to Elasticsearch",
@@ -60,17 +69,49 @@ func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema
 					Optional:      true,
 					Sensitive:     true,
 					DefaultFunc:   withEnvDefault("ELASTICSEARCH_API_KEY", nil),
-					ConflictsWith: []string{usernamePath, passwordPath},
+					ConflictsWith: []string{usernamePath, passwordPath, cloudAuthPath, bearerTokenPath},
+				},
+				"bearer_token": {
+					Description:   "Bearer token to use for authentication to Elasticsearch, such as a service account token or a JWT.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   withEnvDefault("ELASTICSEARCH_BEARER_TOKEN", nil),
+					ConflictsWith: []string{usernamePath, passwordPath, apiKeyPath, cloudAuthPath},
+				},
+				"es_client_authentication": {
+					Description:   "Shared secret of the JWT realm, sent in the `ES-Client-Authentication` header along with the JWT given in `bearer_token`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   withEnvDefault("ELASTICSEARCH_ES_CLIENT_AUTHENTICATION", nil),
+					ConflictsWith: []string{usernamePath, passwordPath, apiKeyPath, cloudAuthPath},
 				},
 				"endpoints": {
-					Description: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
-					Type:        schema.TypeList,
-					Optional:    true,
-					Sensitive:   true,
+					Description:   "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Type:          schema.TypeList,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{cloudIDPath},
 					Elem: &schema.Schema{
 						Type: schema.TypeString,
 					},
 				},
+				"cloud_id": {
+					Description:   "The Cloud ID of an Elastic Cloud deployment. The Elasticsearch, Kibana and Fleet endpoints are derived from it.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   withEnvDefault("ELASTIC_CLOUD_ID", nil),
+					ConflictsWith: []string{endpointsPath},
+				},
+				"cloud_auth": {
+					Description:   "Elastic Cloud credentials, formatted as `<username>:<password>`, to use instead of `username` and `password`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   withEnvDefault("ELASTIC_CLOUD_AUTH", nil),
+					ConflictsWith: []string{usernamePath, passwordPath, apiKeyPath, bearerTokenPath, esClientAuthenticationPath},
+				},
 				"insecure": {
 					Description: "Disable TLS certificate validation",
 					Type:        schema.TypeBool,
@@ -123,37 +164,102 @@ func GetEsConnectionSchema(keyName string, isProviderConfiguration bool) *schema
 	}
 }
 
-func GetKibanaConnectionSchema() *schema.Schema {
+func GetKibanaConnectionSchema(keyName string) *schema.Schema {
 	return &schema.Schema{
-		Description: "Kibana connection configuration block.",
+		Description: "Kibana connection configuration block. Without credentials, the ones of the Elasticsearch connection are used.",
 		Type:        schema.TypeList,
 		MaxItems:    1,
 		Optional:    true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"username": {
-					Description:  "Username to use for API authentication to Kibana.",
-					Type:         schema.TypeString,
-					Optional:     true,
-					RequiredWith: []string{"kibana.0.password"},
+					Description:   "Username to use for API authentication to Kibana.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{makePathRef(keyName, "password")},
+					ConflictsWith: []string{makePathRef(keyName, "api_key"), makePathRef(keyName, "bearer_token")},
 				},
 				"password": {
-					Description:  "Password to use for API authentication to Kibana.",
-					Type:         schema.TypeString,
-					Optional:     true,
-					Sensitive:    true,
-					RequiredWith: []string{"kibana.0.username"},
+					Description:   "Password to use for API authentication to Kibana.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					RequiredWith:  []string{makePathRef(keyName, "username")},
+					ConflictsWith: []string{makePathRef(keyName, "api_key"), makePathRef(keyName, "bearer_token")},
+				},
+				"api_key": {
+					Description:   "API Key to use for authentication to Kibana",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{makePathRef(keyName, "username"), makePathRef(keyName, "password"), makePathRef(keyName, "bearer_token")},
+				},
+				"bearer_token": {
+					Description:   "Bearer token to use for authentication to Kibana",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{makePathRef(keyName, "username"), makePathRef(keyName, "password"), makePathRef(keyName, "api_key")},
 				},
 				"endpoints": {
-					Description: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Description:   "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Type:          schema.TypeList,
+					Optional:      true,
+					Sensitive:     true,
+					MaxItems:      1, // Current API restriction
+					ConflictsWith: []string{makePathRef(keyName, "cloud_id")},
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"cloud_id": {
+					Description:   "The Cloud ID of an Elastic Cloud deployment. The Kibana and Fleet endpoints are derived from it. Defaults to the `cloud_id` of the Elasticsearch connection.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{makePathRef(keyName, "endpoints")},
+				},
+				"ca_certs": {
+					Description: "A list of paths to CA certificates to validate the certificate presented by the Kibana server.",
 					Type:        schema.TypeList,
 					Optional:    true,
-					Sensitive:   true,
-					MaxItems:    1, // Current API restriction
 					Elem: &schema.Schema{
 						Type: schema.TypeString,
 					},
 				},
+				"ca_data": {
+					Description: "PEM-encoded custom Certificate Authority certificate",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"cert_file": {
+					Description:   "Path to a file containing the PEM encoded certificate for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{makePathRef(keyName, "key_file")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_data"), makePathRef(keyName, "key_data")},
+				},
+				"key_file": {
+					Description:   "Path to a file containing the PEM encoded private key for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{makePathRef(keyName, "cert_file")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_data"), makePathRef(keyName, "key_data")},
+				},
+				"cert_data": {
+					Description:   "PEM encoded certificate for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{makePathRef(keyName, "key_data")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_file"), makePathRef(keyName, "key_file")},
+				},
+				"key_data": {
+					Description:   "PEM encoded private key for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					RequiredWith:  []string{makePathRef(keyName, "cert_data")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_file"), makePathRef(keyName, "key_file")},
+				},
 				"insecure": {
 					Description: "Disable TLS certificate validation",
 					Type:        schema.TypeBool,
@@ -165,7 +271,7 @@ func GetKibanaConnectionSchema() *schema.Schema {
 	}
 }
 
-func GetFleetConnectionSchema() *schema.Schema {
+func GetFleetConnectionSchema(keyName string) *schema.Schema {
 	return &schema.Schema{
 		Description: "Fleet connection configuration block.",
 		Type:        schema.TypeList,
@@ -177,14 +283,14 @@ func GetFleetConnectionSchema() *schema.Schema {
 					Description:  "Username to use for API authentication to Fleet.",
 					Type:         schema.TypeString,
 					Optional:     true,
-					RequiredWith: []string{"fleet.0.password"},
+					RequiredWith: []string{makePathRef(keyName, "password")},
 				},
 				"password": {
 					Description:  "Password to use for API authentication to Fleet.",
 					Type:         schema.TypeString,
 					Optional:     true,
 					Sensitive:    true,
-					RequiredWith: []string{"fleet.0.username"},
+					RequiredWith: []string{makePathRef(keyName, "username")},
 				},
 				"api_key": {
 					Description: "API key to use for API authentication to Fleet.",
@@ -206,6 +312,40 @@ func GetFleetConnectionSchema() *schema.Schema {
 						Type: schema.TypeString,
 					},
 				},
+				"ca_data": {
+					Description: "PEM-encoded custom Certificate Authority certificate",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"cert_file": {
+					Description:   "Path to a file containing the PEM encoded certificate for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{makePathRef(keyName, "key_file")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_data"), makePathRef(keyName, "key_data")},
+				},
+				"key_file": {
+					Description:   "Path to a file containing the PEM encoded private key for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{makePathRef(keyName, "cert_file")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_data"), makePathRef(keyName, "key_data")},
+				},
+				"cert_data": {
+					Description:   "PEM encoded certificate for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					RequiredWith:  []string{makePathRef(keyName, "key_data")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_file"), makePathRef(keyName, "key_file")},
+				},
+				"key_data": {
+					Description:   "PEM encoded private key for client auth",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					RequiredWith:  []string{makePathRef(keyName, "cert_data")},
+					ConflictsWith: []string{makePathRef(keyName, "cert_file"), makePathRef(keyName, "key_file")},
+				},
 				"insecure": {
 					Description: "Disable TLS certificate validation",
 					Type:        schema.TypeBool,
@@ -217,6 +357,47 @@ func GetFleetConnectionSchema() *schema.Schema {
 	}
 }
 
+func GetRetrySchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Retry policy for requests failing with a transient error, applied to the Elasticsearch, Kibana and Fleet clients.",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_retries": {
+					Description:  "Maximum number of times a request is retried. Set to `0` to disable retries.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      3,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"min_backoff": {
+					Description: "Delay before the first retry, e.g. `1s`. The delay doubles on each following retry.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "1s",
+				},
+				"max_backoff": {
+					Description: "Maximum delay between two retries, e.g. `30s`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "30s",
+				},
+				"retryable_status_codes": {
+					Description: "HTTP status codes which are retried. Defaults to `429`, `502`, `503` and `504`. Requests which aren't idempotent, e.g. `POST` requests, are only retried on `429`.",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IntBetween(100, 599),
+					},
+				},
+			},
+		},
+	}
+}
+
 func makePathRef(keyName string, keyValue string) string {
 	return fmt.Sprintf("%s.0.%s", keyName, keyValue)
 }
@@ -0,0 +1,334 @@
+package schema
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The plugin framework provider is served alongside the SDKv2 provider, and the two provider schemas must be identical.
+// Attribute level validation (conflicting or required attributes) is performed by the SDKv2 provider, and is not
+// duplicated here to avoid reporting every configuration error twice.
+
+func GetEsFWConnectionBlock() fwschema.Block {
+	return fwschema.ListNestedBlock{
+		MarkdownDescription: "Elasticsearch connection configuration block. ",
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: fwschema.NestedBlockObject{
+			Attributes: map[string]fwschema.Attribute{
+				"username": fwschema.StringAttribute{
+					MarkdownDescription: "Username to use for API authentication to Elasticsearch.",
+					Optional:            true,
+				},
+				"password": fwschema.StringAttribute{
+					MarkdownDescription: "Password to use for API authentication to Elasticsearch.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"api_key": fwschema.StringAttribute{
+					MarkdownDescription: "API Key to use for authentication to Elasticsearch",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"bearer_token": fwschema.StringAttribute{
+					MarkdownDescription: "Bearer token to use for authentication to Elasticsearch, such as a service account token or a JWT.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"es_client_authentication": fwschema.StringAttribute{
+					MarkdownDescription: "Shared secret of the JWT realm, sent in the `ES-Client-Authentication` header along with the JWT given in `bearer_token`.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"endpoints": fwschema.ListAttribute{
+					MarkdownDescription: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Optional:            true,
+					Sensitive:           true,
+					ElementType:         types.StringType,
+				},
+				"cloud_id": fwschema.StringAttribute{
+					MarkdownDescription: "The Cloud ID of an Elastic Cloud deployment. The Elasticsearch, Kibana and Fleet endpoints are derived from it.",
+					Optional:            true,
+				},
+				"cloud_auth": fwschema.StringAttribute{
+					MarkdownDescription: "Elastic Cloud credentials, formatted as `<username>:<password>`, to use instead of `username` and `password`.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"insecure": fwschema.BoolAttribute{
+					MarkdownDescription: "Disable TLS certificate validation",
+					Optional:            true,
+				},
+				"ca_file": fwschema.StringAttribute{
+					MarkdownDescription: "Path to a custom Certificate Authority certificate",
+					Optional:            true,
+				},
+				"ca_data":   fwCADataAttribute(),
+				"cert_file": fwCertFileAttribute(),
+				"key_file":  fwKeyFileAttribute(),
+				"cert_data": fwCertDataAttribute(),
+				"key_data":  fwKeyDataAttribute(),
+			},
+		},
+	}
+}
+
+func GetKbFWConnectionBlock() fwschema.Block {
+	return fwschema.ListNestedBlock{
+		MarkdownDescription: "Kibana connection configuration block. Without credentials, the ones of the Elasticsearch connection are used.",
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: fwschema.NestedBlockObject{
+			Attributes: map[string]fwschema.Attribute{
+				"username": fwschema.StringAttribute{
+					MarkdownDescription: "Username to use for API authentication to Kibana.",
+					Optional:            true,
+				},
+				"password": fwschema.StringAttribute{
+					MarkdownDescription: "Password to use for API authentication to Kibana.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"api_key": fwschema.StringAttribute{
+					MarkdownDescription: "API Key to use for authentication to Kibana",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"bearer_token": fwschema.StringAttribute{
+					MarkdownDescription: "Bearer token to use for authentication to Kibana",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"endpoints": fwschema.ListAttribute{
+					MarkdownDescription: "A list of endpoints where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Optional:            true,
+					Sensitive:           true,
+					ElementType:         types.StringType,
+				},
+				"cloud_id": fwschema.StringAttribute{
+					MarkdownDescription: "The Cloud ID of an Elastic Cloud deployment. The Kibana and Fleet endpoints are derived from it. Defaults to the `cloud_id` of the Elasticsearch connection.",
+					Optional:            true,
+				},
+				"ca_certs": fwschema.ListAttribute{
+					MarkdownDescription: "A list of paths to CA certificates to validate the certificate presented by the Kibana server.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"ca_data":   fwCADataAttribute(),
+				"cert_file": fwCertFileAttribute(),
+				"key_file":  fwKeyFileAttribute(),
+				"cert_data": fwCertDataAttribute(),
+				"key_data":  fwKeyDataAttribute(),
+				"insecure": fwschema.BoolAttribute{
+					MarkdownDescription: "Disable TLS certificate validation",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+func GetFleetFWConnectionBlock() fwschema.Block {
+	return fwschema.ListNestedBlock{
+		MarkdownDescription: "Fleet connection configuration block.",
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: fwschema.NestedBlockObject{
+			Attributes: map[string]fwschema.Attribute{
+				"username": fwschema.StringAttribute{
+					MarkdownDescription: "Username to use for API authentication to Fleet.",
+					Optional:            true,
+				},
+				"password": fwschema.StringAttribute{
+					MarkdownDescription: "Password to use for API authentication to Fleet.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"api_key": fwschema.StringAttribute{
+					MarkdownDescription: "API key to use for API authentication to Fleet.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"endpoint": fwschema.StringAttribute{
+					MarkdownDescription: "The Fleet server where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"ca_certs": fwschema.ListAttribute{
+					MarkdownDescription: "A list of paths to CA certificates to validate the certificate presented by the Fleet server.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"ca_data":   fwCADataAttribute(),
+				"cert_file": fwCertFileAttribute(),
+				"key_file":  fwKeyFileAttribute(),
+				"cert_data": fwCertDataAttribute(),
+				"key_data":  fwKeyDataAttribute(),
+				"insecure": fwschema.BoolAttribute{
+					MarkdownDescription: "Disable TLS certificate validation",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+func GetRetryFWBlock() fwschema.Block {
+	return fwschema.ListNestedBlock{
+		MarkdownDescription: "Retry policy for requests failing with a transient error, applied to the Elasticsearch, Kibana and Fleet clients.",
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: fwschema.NestedBlockObject{
+			Attributes: map[string]fwschema.Attribute{
+				"max_retries": fwschema.Int64Attribute{
+					MarkdownDescription: "Maximum number of times a request is retried. Set to `0` to disable retries.",
+					Optional:            true,
+				},
+				"min_backoff": fwschema.StringAttribute{
+					MarkdownDescription: "Delay before the first retry, e.g. `1s`. The delay doubles on each following retry.",
+					Optional:            true,
+				},
+				"max_backoff": fwschema.StringAttribute{
+					MarkdownDescription: "Maximum delay between two retries, e.g. `30s`.",
+					Optional:            true,
+				},
+				"retryable_status_codes": fwschema.ListAttribute{
+					MarkdownDescription: "HTTP status codes which are retried. Defaults to `429`, `502`, `503` and `504`. Requests which aren't idempotent, e.g. `POST` requests, are only retried on `429`.",
+					Optional:            true,
+					ElementType:         types.Int64Type,
+				},
+			},
+		},
+	}
+}
+
+func fwCADataAttribute() fwschema.Attribute {
+	return fwschema.StringAttribute{
+		MarkdownDescription: "PEM-encoded custom Certificate Authority certificate",
+		Optional:            true,
+	}
+}
+
+func fwCertFileAttribute() fwschema.Attribute {
+	return fwschema.StringAttribute{
+		MarkdownDescription: "Path to a file containing the PEM encoded certificate for client auth",
+		Optional:            true,
+	}
+}
+
+func fwKeyFileAttribute() fwschema.Attribute {
+	return fwschema.StringAttribute{
+		MarkdownDescription: "Path to a file containing the PEM encoded private key for client auth",
+		Optional:            true,
+	}
+}
+
+func fwCertDataAttribute() fwschema.Attribute {
+	return fwschema.StringAttribute{
+		MarkdownDescription: "PEM encoded certificate for client auth",
+		Optional:            true,
+	}
+}
+
+func fwKeyDataAttribute() fwschema.Attribute {
+	return fwschema.StringAttribute{
+		MarkdownDescription: "PEM encoded private key for client auth",
+		Optional:            true,
+		Sensitive:           true,
+	}
+}
+
+// GetFleetFWResourceConnectionBlock returns the block used by the plugin framework Fleet resources to target a
+// different Fleet server than the provider. Unlike the provider blocks, it has no SDKv2 counterpart validating it.
+func GetFleetFWResourceConnectionBlock() rschema.Block {
+	sibling := func(name string) path.Expression {
+		return path.MatchRelative().AtParent().AtName(name)
+	}
+
+	return rschema.ListNestedBlock{
+		MarkdownDescription: "Fleet connection configuration block.",
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+		NestedObject: rschema.NestedBlockObject{
+			Attributes: map[string]rschema.Attribute{
+				"username": rschema.StringAttribute{
+					MarkdownDescription: "Username to use for API authentication to Fleet.",
+					Optional:            true,
+					Validators:          []validator.String{stringvalidator.AlsoRequires(sibling("password"))},
+				},
+				"password": rschema.StringAttribute{
+					MarkdownDescription: "Password to use for API authentication to Fleet.",
+					Optional:            true,
+					Sensitive:           true,
+					Validators:          []validator.String{stringvalidator.AlsoRequires(sibling("username"))},
+				},
+				"api_key": rschema.StringAttribute{
+					MarkdownDescription: "API key to use for API authentication to Fleet.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"endpoint": rschema.StringAttribute{
+					MarkdownDescription: "The Fleet server where the terraform provider will point to, this must include the http(s) schema and port number.",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"ca_certs": rschema.ListAttribute{
+					MarkdownDescription: "A list of paths to CA certificates to validate the certificate presented by the Fleet server.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"ca_data": rschema.StringAttribute{
+					MarkdownDescription: "PEM-encoded custom Certificate Authority certificate",
+					Optional:            true,
+				},
+				"cert_file": rschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(sibling("key_file")),
+						stringvalidator.ConflictsWith(sibling("cert_data"), sibling("key_data")),
+					},
+				},
+				"key_file": rschema.StringAttribute{
+					MarkdownDescription: "Path to a file containing the PEM encoded private key for client auth",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(sibling("cert_file")),
+						stringvalidator.ConflictsWith(sibling("cert_data"), sibling("key_data")),
+					},
+				},
+				"cert_data": rschema.StringAttribute{
+					MarkdownDescription: "PEM encoded certificate for client auth",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(sibling("key_data")),
+						stringvalidator.ConflictsWith(sibling("cert_file"), sibling("key_file")),
+					},
+				},
+				"key_data": rschema.StringAttribute{
+					MarkdownDescription: "PEM encoded private key for client auth",
+					Optional:            true,
+					Sensitive:           true,
+					Validators: []validator.String{
+						stringvalidator.AlsoRequires(sibling("cert_data")),
+						stringvalidator.ConflictsWith(sibling("cert_file"), sibling("key_file")),
+					},
+				},
+				"insecure": rschema.BoolAttribute{
+					MarkdownDescription: "Disable TLS certificate validation",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
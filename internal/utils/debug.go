@@ -3,30 +3,160 @@ package utils
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
+	"os"
 	"strings"
 )
 
+// RedactedPathsEnvVar is the environment variable listing extra JSON paths to mask in the debug logs, separated by
+// commas, e.g. `config.password,metadata.owner`.
+const RedactedPathsEnvVar = "ELASTICSTACK_LOG_REDACTED_PATHS"
+
+const redactedValue = "********"
+
+// redactedHeaders are the headers carrying credentials.
+var redactedHeaders = []string{"Authorization", "Es-Client-Authentication", "Cookie", "Set-Cookie"}
+
+// redactedPaths are the JSON paths of the secrets sent to or returned by the APIs. A path matches the attributes it
+// ends with, at any depth, and array items are ignored: `ssl.key` matches `{"secrets": {"ssl": {"key": ...}}}`.
+var redactedPaths = []string{
+	// Elasticsearch users and API keys.
+	"password",
+	"password_hash",
+	"api_key",
+	"encoded",
+	// Elasticsearch service and OAuth tokens.
+	"token",
+	"access_token",
+	"refresh_token",
+	// Kibana connectors and Fleet outputs.
+	"secrets",
+	"ssl.key",
+}
+
 // PrettyPrintJSONLines iterates through a []byte line-by-line,
 // transforming any lines that are complete json into pretty-printed json.
+// The credentials and secrets of the HTTP request or response dump are masked.
 func PrettyPrintJSONLines(b []byte) string {
-	parts := strings.Split(string(b), "\n")
+	head, body, found := strings.Cut(string(b), "\r\n\r\n")
+	if !found {
+		head, body = "", head
+	}
+
+	lines := strings.Split(head, "\n")
+	for i, line := range lines {
+		name, _, ok := strings.Cut(line, ": ")
+		if ok && isRedactedHeader(name) {
+			lines[i] = name + ": " + redactedValue
+			if strings.HasSuffix(line, "\r") {
+				lines[i] += "\r"
+			}
+		}
+	}
+
+	if !found {
+		return RedactBody(body)
+	}
+	return strings.Join(lines, "\n") + "\r\n\r\n" + RedactBody(body)
+}
+
+// RedactBody masks the secrets of a JSON body, or of each line of a NDJSON body, and pretty-prints the JSON.
+// Other content is returned as is.
+func RedactBody(body string) string {
+	if redacted, ok := redactJSON(body); ok {
+		return redacted
+	}
+
+	parts := strings.Split(body, "\n")
 	for i, p := range parts {
-		if b := []byte(p); json.Valid(b) {
-			var out bytes.Buffer
-			if err := json.Indent(&out, b, "", " "); err != nil {
+		if redacted, ok := redactJSON(p); ok {
+			parts[i] = redacted
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// RedactHeaders masks the values of the headers carrying credentials.
+func RedactHeaders(header http.Header) {
+	for _, name := range redactedHeaders {
+		if _, ok := header[name]; ok {
+			header[name] = []string{redactedValue}
+		}
+	}
+}
+
+func isRedactedHeader(name string) bool {
+	for _, h := range redactedHeaders {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactJSON(s string) (string, bool) {
+	b := []byte(strings.TrimSpace(s))
+	if len(b) == 0 || !json.Valid(b) {
+		return "", false
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	// Numbers are kept as is.
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+
+	paths := append([]string{}, redactedPaths...)
+	for _, path := range strings.Split(os.Getenv(RedactedPathsEnvVar), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	out, err := json.MarshalIndent(redactValue(value, nil, paths), "", " ")
+	if err != nil {
+		return "", false
+	}
+	return string(out), true
+}
+
+func redactValue(value interface{}, path []string, paths []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, attr := range v {
+			attrPath := append(path[:len(path):len(path)], key)
+			if attr != nil && matchesPath(attrPath, paths) {
+				v[key] = redactedValue
 				continue
 			}
-			parts[i] = out.String()
+			v[key] = redactValue(attr, attrPath, paths)
 		}
-		// Mask Authorization header value
-		if strings.Contains(strings.ToLower(p), "authorization:") {
-			kv := strings.Split(p, ": ")
-			if len(kv) != 2 {
-				continue
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, path, paths)
+		}
+	}
+	return value
+}
+
+func matchesPath(path []string, paths []string) bool {
+	for _, p := range paths {
+		suffix := strings.Split(p, ".")
+		if len(suffix) > len(path) {
+			continue
+		}
+		matches := true
+		for i, key := range suffix {
+			if path[len(path)-len(suffix)+i] != key {
+				matches = false
+				break
 			}
-			kv[1] = strings.Repeat("*", len(kv[1]))
-			parts[i] = strings.Join(kv, ": ")
+		}
+		if matches {
+			return true
 		}
 	}
-	return strings.Join(parts, "\n")
+	return false
 }
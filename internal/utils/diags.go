@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// FrameworkDiagsFromSDK converts SDKv2 diagnostics, as returned by the shared client functions,
+// into plugin framework diagnostics.
+func FrameworkDiagsFromSDK(sdkDiags diag.Diagnostics) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	for _, d := range sdkDiags {
+		attrPath, hasPath := frameworkPathFromCty(d.AttributePath)
+		switch {
+		case d.Severity == diag.Error && hasPath:
+			diags.AddAttributeError(attrPath, d.Summary, d.Detail)
+		case d.Severity == diag.Error:
+			diags.AddError(d.Summary, d.Detail)
+		case d.Severity == diag.Warning && hasPath:
+			diags.AddAttributeWarning(attrPath, d.Summary, d.Detail)
+		case d.Severity == diag.Warning:
+			diags.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	return diags
+}
+
+func frameworkPathFromCty(ctyPath cty.Path) (path.Path, bool) {
+	if len(ctyPath) == 0 {
+		return path.Empty(), false
+	}
+
+	var attrPath path.Path
+	for i, step := range ctyPath {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			if i == 0 {
+				attrPath = path.Root(s.Name)
+			} else {
+				attrPath = attrPath.AtName(s.Name)
+			}
+		case cty.IndexStep:
+			if i == 0 {
+				return path.Empty(), false
+			}
+			switch s.Key.Type() {
+			case cty.Number:
+				index, _ := s.Key.AsBigFloat().Int64()
+				attrPath = attrPath.AtListIndex(int(index))
+			case cty.String:
+				attrPath = attrPath.AtMapKey(s.Key.AsString())
+			default:
+				return path.Empty(), false
+			}
+		}
+	}
+	return attrPath, true
+}
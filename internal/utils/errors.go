@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// ElasticsearchError is the error envelope returned by the Elasticsearch APIs.
+type ElasticsearchError struct {
+	Status int                     `json:"status"`
+	Cause  ElasticsearchErrorCause `json:"error"`
+}
+
+// ElasticsearchErrorCause describes a single error, along with the errors which caused it.
+type ElasticsearchErrorCause struct {
+	Type      string                    `json:"type"`
+	Reason    string                    `json:"reason"`
+	RootCause []ElasticsearchErrorCause `json:"root_cause"`
+	CausedBy  *ElasticsearchErrorCause  `json:"caused_by"`
+}
+
+// UnmarshalJSON also accepts the plain string errors returned by a few APIs.
+func (c *ElasticsearchErrorCause) UnmarshalJSON(data []byte) error {
+	var reason string
+	if err := json.Unmarshal(data, &reason); err == nil {
+		*c = ElasticsearchErrorCause{Reason: reason}
+		return nil
+	}
+
+	type cause ElasticsearchErrorCause
+	return json.Unmarshal(data, (*cause)(c))
+}
+
+func (c ElasticsearchErrorCause) String() string {
+	if c.Type == "" {
+		return c.Reason
+	}
+	return fmt.Sprintf("[%s] %s", c.Type, c.Reason)
+}
+
+// decodeElasticsearchError decodes the body of a failed Elasticsearch request, and returns false when it isn't an
+// Elasticsearch error.
+func decodeElasticsearchError(statusCode int, body []byte) (*ElasticsearchError, bool) {
+	var esErr ElasticsearchError
+	if err := json.Unmarshal(body, &esErr); err != nil || (esErr.Cause.Type == "" && esErr.Cause.Reason == "") {
+		return nil, false
+	}
+	if esErr.Status == 0 {
+		esErr.Status = statusCode
+	}
+	return &esErr, true
+}
+
+func (e *ElasticsearchError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Elasticsearch returned HTTP %d: %s", e.Status, e.Cause)
+
+	seen := map[string]bool{e.Cause.String(): true}
+	for cause := e.Cause.CausedBy; cause != nil; cause = cause.CausedBy {
+		seen[cause.String()] = true
+		fmt.Fprintf(&sb, "\nCaused by: %s", cause)
+	}
+	for _, rootCause := range e.Cause.RootCause {
+		if !seen[rootCause.String()] {
+			seen[rootCause.String()] = true
+			fmt.Fprintf(&sb, "\nRoot cause: %s", rootCause)
+		}
+	}
+	return sb.String()
+}
+
+// causes returns the error, the errors which caused it and its root causes.
+func (e *ElasticsearchError) causes() []ElasticsearchErrorCause {
+	causes := []ElasticsearchErrorCause{e.Cause}
+	for cause := e.Cause.CausedBy; cause != nil; cause = cause.CausedBy {
+		causes = append(causes, *cause)
+	}
+	return append(causes, e.Cause.RootCause...)
+}
+
+// HasType returns whether the error, or any of its causes, has one of the given types.
+func (e *ElasticsearchError) HasType(types ...string) bool {
+	for _, cause := range e.causes() {
+		for _, t := range types {
+			if cause.Type == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var settingReasonRegexp = regexp.MustCompile(`settings? \[\[?([^\[\],\s]+)`)
+
+// Setting returns the name of the index setting the error was reported for, if any.
+func (e *ElasticsearchError) Setting() (string, bool) {
+	for _, cause := range e.causes() {
+		if cause.Type != "illegal_argument_exception" && cause.Type != "settings_exception" {
+			continue
+		}
+		if match := settingReasonRegexp.FindStringSubmatch(cause.Reason); match != nil {
+			return match[1], true
+		}
+	}
+	return "", false
+}
+
+// ErrorPathResolver returns the path of the attribute which caused the Elasticsearch error, or nil when unknown.
+type ErrorPathResolver func(*ElasticsearchError) cty.Path
+
+// MappingsErrorPath reports mapping errors on the attribute at the given path.
+func MappingsErrorPath(path cty.Path) ErrorPathResolver {
+	return func(e *ElasticsearchError) cty.Path {
+		if e.HasType("mapper_parsing_exception", "mapper_exception", "strict_dynamic_mapping_exception") {
+			return path
+		}
+		return nil
+	}
+}
+
+// SettingErrorPath reports errors on an index setting on the attribute returned by settingPath.
+func SettingErrorPath(settingPath func(setting string) cty.Path) ErrorPathResolver {
+	return func(e *ElasticsearchError) cty.Path {
+		if setting, ok := e.Setting(); ok {
+			return settingPath(setting)
+		}
+		return nil
+	}
+}
+
+// KibanaError is the error body returned by the Kibana APIs.
+type KibanaError struct {
+	StatusCode int    `json:"statusCode"`
+	Err        string `json:"error"`
+	Message    string `json:"message"`
+}
+
+// decodeKibanaError decodes the body of a failed Kibana request, and returns false when it isn't a Kibana error.
+func decodeKibanaError(statusCode int, body []byte) (*KibanaError, bool) {
+	var kibErr KibanaError
+	if err := json.Unmarshal(body, &kibErr); err != nil || kibErr.Message == "" {
+		return nil, false
+	}
+	if kibErr.StatusCode == 0 {
+		kibErr.StatusCode = statusCode
+	}
+	if kibErr.Err == "" {
+		kibErr.Err = http.StatusText(kibErr.StatusCode)
+	}
+	return &kibErr, true
+}
+
+func (e *KibanaError) Error() string {
+	return fmt.Sprintf("Kibana returned HTTP %d %s: %s", e.StatusCode, e.Err, e.Message)
+}
+
+var requestBodyFieldRegexp = regexp.MustCompile(`\[request body\.([^\]]+)\]`)
+
+// RequestBodyField returns the request body field the validation error was reported for, if any.
+func (e *KibanaError) RequestBodyField() (string, bool) {
+	if match := requestBodyFieldRegexp.FindStringSubmatch(e.Message); match != nil {
+		return match[1], true
+	}
+	return "", false
+}
+
+// KibanaErrorPathResolver returns the path of the attribute which caused the Kibana error, or nil when unknown.
+type KibanaErrorPathResolver func(*KibanaError) cty.Path
+
+// RequestBodyErrorPath reports validation errors on the attribute returned by fieldPath for the request body field.
+func RequestBodyErrorPath(fieldPath func(field string) cty.Path) KibanaErrorPathResolver {
+	return func(e *KibanaError) cty.Path {
+		if field, ok := e.RequestBodyField(); ok {
+			return fieldPath(field)
+		}
+		return nil
+	}
+}
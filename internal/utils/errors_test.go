@@ -0,0 +1,188 @@
+package utils_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckError(t *testing.T) {
+	t.Parallel()
+
+	resolvers := []utils.ErrorPathResolver{
+		utils.MappingsErrorPath(cty.GetAttrPath("mappings")),
+		utils.SettingErrorPath(func(setting string) cty.Path {
+			return cty.GetAttrPath("settings").IndexString(setting)
+		}),
+	}
+
+	tests := []struct {
+		name           string
+		statusCode     int
+		body           string
+		expectedDetail string
+		expectedPath   cty.Path
+	}{
+		{
+			name:       "mapping errors are reported on the mappings",
+			statusCode: http.StatusBadRequest,
+			body: `{
+				"error": {
+					"root_cause": [{"type": "mapper_parsing_exception", "reason": "No handler for type [strin] declared on field [name]"}],
+					"type": "mapper_parsing_exception",
+					"reason": "Failed to parse mapping [_doc]: No handler for type [strin] declared on field [name]",
+					"caused_by": {"type": "mapper_parsing_exception", "reason": "No handler for type [strin] declared on field [name]"}
+				},
+				"status": 400
+			}`,
+			expectedDetail: "Elasticsearch returned HTTP 400: [mapper_parsing_exception] Failed to parse mapping [_doc]: No handler for type [strin] declared on field [name]\n" +
+				"Caused by: [mapper_parsing_exception] No handler for type [strin] declared on field [name]",
+			expectedPath: cty.GetAttrPath("mappings"),
+		},
+		{
+			name:       "setting errors are reported on the setting",
+			statusCode: http.StatusBadRequest,
+			body: `{
+				"error": {
+					"root_cause": [{"type": "illegal_argument_exception", "reason": "unknown setting [index.foo] please check that any required plugins are installed"}],
+					"type": "illegal_argument_exception",
+					"reason": "unknown setting [index.foo] please check that any required plugins are installed"
+				},
+				"status": 400
+			}`,
+			expectedDetail: "Elasticsearch returned HTTP 400: [illegal_argument_exception] unknown setting [index.foo] please check that any required plugins are installed",
+			expectedPath:   cty.GetAttrPath("settings").IndexString("index.foo"),
+		},
+		{
+			name:       "non dynamic settings are reported on the setting",
+			statusCode: http.StatusBadRequest,
+			body: `{
+				"error": {
+					"type": "illegal_argument_exception",
+					"reason": "Can't update non dynamic settings [[index.codec]] for open indices [[my-index/abc]]",
+					"root_cause": [{"type": "remote_transport_exception", "reason": "[node][indices:admin/settings/update]"}]
+				},
+				"status": 400
+			}`,
+			expectedDetail: "Elasticsearch returned HTTP 400: [illegal_argument_exception] Can't update non dynamic settings [[index.codec]] for open indices [[my-index/abc]]\n" +
+				"Root cause: [remote_transport_exception] [node][indices:admin/settings/update]",
+			expectedPath: cty.GetAttrPath("settings").IndexString("index.codec"),
+		},
+		{
+			name:           "other errors are reported on the resource",
+			statusCode:     http.StatusNotFound,
+			body:           `{"error": {"type": "index_not_found_exception", "reason": "no such index [my-index]"}, "status": 404}`,
+			expectedDetail: "Elasticsearch returned HTTP 404: [index_not_found_exception] no such index [my-index]",
+		},
+		{
+			name:           "plain string errors",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"error": "Incorrect HTTP method", "status": 405}`,
+			expectedDetail: "Elasticsearch returned HTTP 405: Incorrect HTTP method",
+		},
+		{
+			name:           "unknown bodies are reported as is",
+			statusCode:     http.StatusBadGateway,
+			body:           `Bad Gateway`,
+			expectedDetail: "Failed with: Bad Gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := &esapi.Response{StatusCode: tt.statusCode, Body: io.NopCloser(strings.NewReader(tt.body))}
+			diags := utils.CheckError(res, "Unable to create index", resolvers...)
+			require.Equal(t, diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Unable to create index",
+				Detail:        tt.expectedDetail,
+				AttributePath: tt.expectedPath,
+			}}, diags)
+		})
+	}
+}
+
+func TestCheckHttpError(t *testing.T) {
+	t.Parallel()
+
+	resolver := utils.RequestBodyErrorPath(func(field string) cty.Path {
+		return cty.GetAttrPath(field)
+	})
+
+	tests := []struct {
+		name           string
+		statusCode     int
+		body           string
+		expectedDetail string
+		expectedPath   cty.Path
+	}{
+		{
+			name:           "validation errors are reported on the field",
+			statusCode:     http.StatusBadRequest,
+			body:           `{"statusCode": 400, "error": "Bad Request", "message": "[request body.name]: expected value of type [string] but got [undefined]"}`,
+			expectedDetail: "Kibana returned HTTP 400 Bad Request: [request body.name]: expected value of type [string] but got [undefined]",
+			expectedPath:   cty.GetAttrPath("name"),
+		},
+		{
+			name:           "other errors are reported on the resource",
+			statusCode:     http.StatusForbidden,
+			body:           `{"statusCode": 403, "error": "Forbidden", "message": "Unauthorized to create a rule"}`,
+			expectedDetail: "Kibana returned HTTP 403 Forbidden: Unauthorized to create a rule",
+		},
+		{
+			name:           "the error defaults to the status text",
+			statusCode:     http.StatusConflict,
+			body:           `{"message": "Rule already exists"}`,
+			expectedDetail: "Kibana returned HTTP 409 Conflict: Rule already exists",
+		},
+		{
+			name:           "unknown bodies are reported as is",
+			statusCode:     http.StatusBadGateway,
+			body:           `Bad Gateway`,
+			expectedDetail: "Failed with: Bad Gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := &http.Response{StatusCode: tt.statusCode, Body: io.NopCloser(strings.NewReader(tt.body))}
+			diags := utils.CheckHttpError(res, "Unable to create rule", resolver)
+			require.Equal(t, diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Unable to create rule",
+				Detail:        tt.expectedDetail,
+				AttributePath: tt.expectedPath,
+			}}, diags)
+		})
+	}
+}
+
+func TestFrameworkDiagsFromSDK(t *testing.T) {
+	t.Parallel()
+
+	diags := utils.FrameworkDiagsFromSDK(diag.Diagnostics{
+		{Severity: diag.Error, Summary: "no path"},
+		{Severity: diag.Error, Summary: "with path", AttributePath: cty.GetAttrPath("template").IndexInt(0).GetAttr("settings").IndexString("index.codec")},
+	})
+
+	require.Len(t, diags, 2)
+	require.Equal(t, "no path", diags[0].Summary())
+	require.Equal(t, "with path", diags[1].Summary())
+	withPath, ok := diags[1].(interface{ Path() path.Path })
+	require.True(t, ok)
+	require.Equal(t, path.Root("template").AtListIndex(0).AtName("settings").AtMapKey("index.codec"), withPath.Path())
+}
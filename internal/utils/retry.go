@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy configures how requests failing with a transient error are retried.
+type RetryPolicy struct {
+	MaxRetries           int
+	MinBackoff           time.Duration
+	MaxBackoff           time.Duration
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the retry policy used when the provider configuration doesn't define one.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:           3,
+		MinBackoff:           time.Second,
+		MaxBackoff:           30 * time.Second,
+		RetryableStatusCodes: DefaultRetryableStatusCodes(),
+	}
+}
+
+// DefaultRetryableStatusCodes returns the status codes which are retried by default.
+func DefaultRetryableStatusCodes() []int {
+	return []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+}
+
+func (p RetryPolicy) isRetryable(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry reports whether the request can be sent again after failing with the given response or error.
+// Requests which aren't idempotent, e.g. the creation of a Kibana connector, may have been processed by the server
+// when a gateway answers with an error, and are only retried when they weren't: when the server rejected them with a
+// `429` or when the connection couldn't be established.
+func (p RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(req.Method) || isConnectionError(err)
+	}
+	if !p.isRetryable(resp.StatusCode) {
+		return false
+	}
+	return isIdempotent(req.Method) || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// isIdempotent reports whether sending the request several times has the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isConnectionError reports whether the request failed before reaching the server.
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// backoff returns the delay before the given retry. The delay grows exponentially from MinBackoff,
+// unless the server asked for a specific delay with a Retry-After header. It never exceeds MaxBackoff.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return minDuration(time.Duration(seconds)*time.Second, p.MaxBackoff)
+		}
+	}
+
+	delay := p.MinBackoff
+	for i := 0; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return minDuration(delay, p.MaxBackoff)
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+var _ http.RoundTripper = &retryRoundTripper{}
+
+type retryRoundTripper struct {
+	name      string
+	policy    RetryPolicy
+	transport http.RoundTripper
+}
+
+// NewRetryTransport wraps the transport so that requests failing with one of the retryable status codes, or with a
+// network error, are sent again following the retry policy.
+func NewRetryTransport(name string, policy RetryPolicy, transport http.RoundTripper) http.RoundTripper {
+	if policy.MaxRetries <= 0 {
+		return transport
+	}
+
+	return &retryRoundTripper{
+		name:      name,
+		policy:    policy,
+		transport: transport,
+	}
+}
+
+func (t *retryRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+
+	// The body has to be sent again on each retry.
+	req := r.Clone(ctx)
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	for retry := 0; ; retry++ {
+		resp, err := t.transport.RoundTrip(req)
+		if retry >= t.policy.MaxRetries || !t.policy.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.policy.backoff(retry, resp)
+		fields := map[string]interface{}{
+			"method":      req.Method,
+			"url":         req.URL.Redacted(),
+			"retry":       retry + 1,
+			"max_retries": t.policy.MaxRetries,
+			"backoff":     delay.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+			tflog.Warn(ctx, fmt.Sprintf("%s API request failed with a network error", t.name), fields)
+		} else {
+			fields["status_code"] = resp.StatusCode
+			tflog.Warn(ctx, fmt.Sprintf("%s API request failed with a retryable status code", t.name), fields)
+
+			// Drain the body so that the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
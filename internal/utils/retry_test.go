@@ -0,0 +1,175 @@
+package utils_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	policy := utils.RetryPolicy{
+		MaxRetries:           2,
+		MinBackoff:           time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		RetryableStatusCodes: utils.DefaultRetryableStatusCodes(),
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		statusCodes      []int
+		expectedStatus   int
+		expectedAttempts int
+	}{
+		{
+			name:             "retries until the request succeeds",
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "returns the last response once the retries are exhausted",
+			statusCodes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "does not retry other errors",
+			statusCodes:      []int{http.StatusInternalServerError, http.StatusOK},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "does not retry a non-idempotent request the server may have processed",
+			method:           http.MethodPost,
+			statusCodes:      []int{http.StatusBadGateway, http.StatusOK},
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retries a non-idempotent request the server rejected",
+			method:           http.MethodPost,
+			statusCodes:      []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				w.WriteHeader(tt.statusCodes[len(bodies)-1])
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: utils.NewRetryTransport("Test", policy, http.DefaultTransport)}
+			// A reader without a known length, so that the transport has to buffer the body itself.
+			method := tt.method
+			if method == "" {
+				method = http.MethodPut
+			}
+			req, err := http.NewRequest(method, server.URL, io.NopCloser(strings.NewReader(`{"a":1}`)))
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Len(t, bodies, tt.expectedAttempts)
+			for _, body := range bodies {
+				require.Equal(t, `{"a":1}`, body)
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRetryTransportNetworkErrors(t *testing.T) {
+	t.Parallel()
+
+	policy := utils.RetryPolicy{
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		err              error
+		expectedAttempts int
+	}{
+		{
+			name:             "retries an idempotent request",
+			method:           http.MethodGet,
+			err:              io.ErrUnexpectedEOF,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "retries a non-idempotent request which didn't reach the server",
+			method:           http.MethodPost,
+			err:              &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "does not retry a non-idempotent request which may have reached the server",
+			method:           http.MethodPost,
+			err:              io.ErrUnexpectedEOF,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attempts := 0
+			transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				attempts++
+				if attempts == 1 {
+					return nil, tt.err
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			})
+			client := &http.Client{Transport: utils.NewRetryTransport("Test", policy, transport)}
+			req, err := http.NewRequest(tt.method, "http://localhost:9200", nil)
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			require.Equal(t, tt.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryTransportDisabled(t *testing.T) {
+	t.Parallel()
+
+	transport := http.DefaultTransport
+	require.Equal(t, transport, utils.NewRetryTransport("Test", utils.RetryPolicy{}, transport))
+}
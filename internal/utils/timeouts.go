@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DefaultTimeout is the time allowed for each operation of a resource when no `timeouts` are configured.
+const DefaultTimeout = 20 * time.Minute
+
+// WithTimeouts declares the `timeouts` block of the resource, which configures the time allowed for its create, read,
+// update and delete operations. The context of an operation is cancelled once its timeout elapses.
+func WithTimeouts(r *schema.Resource) *schema.Resource {
+	timeout := schema.DefaultTimeout(DefaultTimeout)
+	r.Timeouts = &schema.ResourceTimeout{
+		Create: timeout,
+		Read:   timeout,
+		Delete: timeout,
+	}
+	if r.UpdateContext != nil {
+		r.Timeouts.Update = timeout
+	}
+	return r
+}
+
+// TimeoutFromContext returns the time left before the deadline of the context, rounded down to the second. It's sent
+// as the `master_timeout` and `timeout` of the Elasticsearch APIs supporting them, so that the cluster gives up on the
+// request when the operation times out. It returns false when the context has no deadline.
+func TimeoutFromContext(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	timeout := time.Until(deadline).Truncate(time.Second)
+	if timeout <= 0 {
+		return 0, false
+	}
+	return timeout, true
+}
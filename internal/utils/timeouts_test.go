@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeouts(t *testing.T) {
+	t.Parallel()
+
+	noop := func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil }
+
+	updatable := utils.WithTimeouts(&schema.Resource{CreateContext: noop, ReadContext: noop, UpdateContext: noop, DeleteContext: noop})
+	require.Equal(t, utils.DefaultTimeout, *updatable.Timeouts.Create)
+	require.Equal(t, utils.DefaultTimeout, *updatable.Timeouts.Read)
+	require.Equal(t, utils.DefaultTimeout, *updatable.Timeouts.Update)
+	require.Equal(t, utils.DefaultTimeout, *updatable.Timeouts.Delete)
+
+	forceNew := utils.WithTimeouts(&schema.Resource{CreateContext: noop, ReadContext: noop, DeleteContext: noop})
+	require.Nil(t, forceNew.Timeouts.Update)
+}
+
+func TestTimeoutFromContext(t *testing.T) {
+	t.Parallel()
+
+	_, ok := utils.TimeoutFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second+500*time.Millisecond)
+	defer cancel()
+	timeout, ok := utils.TimeoutFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, 90*time.Second, timeout)
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, ok = utils.TimeoutFromContext(expired)
+	require.False(t, ok)
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSSettings holds the TLS options which can be configured for the Kibana and Fleet connections.
+type TLSSettings struct {
+	Insecure bool
+	CACerts  []string
+	CAData   string
+	CertFile string
+	KeyFile  string
+	CertData string
+	KeyData  string
+}
+
+// ClientConfig builds a tls.Config trusting the configured CAs and presenting the configured client certificate.
+func (s TLSSettings) ClientConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: s.Insecure,
+	}
+
+	if len(s.CACerts) > 0 || s.CAData != "" {
+		caCertPool := x509.NewCertPool()
+		for _, certFile := range s.CACerts {
+			certData, err := os.ReadFile(certFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to open CA certificate file %q: %w", certFile, err)
+			}
+			if ok := caCertPool.AppendCertsFromPEM(certData); !ok {
+				return nil, fmt.Errorf("unable to parse CA certificate file %q", certFile)
+			}
+		}
+		if s.CAData != "" {
+			if ok := caCertPool.AppendCertsFromPEM([]byte(s.CAData)); !ok {
+				return nil, fmt.Errorf("unable to parse PEM-encoded CA certificate")
+			}
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	switch {
+	case s.CertFile != "" || s.KeyFile != "":
+		cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read certificate or key file: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case s.CertData != "" || s.KeyData != "":
+		cert, err := tls.X509KeyPair([]byte(s.CertData), []byte(s.KeyData))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -13,12 +13,15 @@ import (
 
 	"github.com/elastic/go-elasticsearch/v7/esapi"
 	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func CheckError(res *esapi.Response, errMsg string) diag.Diagnostics {
+// CheckError returns an error diagnostic when the Elasticsearch request failed. The error returned by Elasticsearch
+// is reported on the attribute returned by the first of the resolvers which knows about it.
+func CheckError(res *esapi.Response, errMsg string, resolvers ...ErrorPathResolver) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	if res.IsError() {
@@ -26,17 +29,36 @@ func CheckError(res *esapi.Response, errMsg string) diag.Diagnostics {
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		esErr, ok := decodeElasticsearchError(res.StatusCode, body)
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  errMsg,
+				Detail:   fmt.Sprintf("Failed with: %s", body),
+			})
+			return diags
+		}
+
+		var path cty.Path
+		for _, resolve := range resolvers {
+			if path = resolve(esErr); path != nil {
+				break
+			}
+		}
 		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  errMsg,
-			Detail:   fmt.Sprintf("Failed with: %s", body),
+			Severity:      diag.Error,
+			Summary:       errMsg,
+			Detail:        esErr.Error(),
+			AttributePath: path,
 		})
 		return diags
 	}
 	return diags
 }
 
-func CheckHttpError(res *http.Response, errMsg string) diag.Diagnostics {
+// CheckHttpError returns an error diagnostic when the Kibana request failed. The error returned by Kibana
+// is reported on the attribute returned by the first of the resolvers which knows about it.
+func CheckHttpError(res *http.Response, errMsg string, resolvers ...KibanaErrorPathResolver) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	if res.StatusCode >= 400 {
@@ -44,10 +66,27 @@ func CheckHttpError(res *http.Response, errMsg string) diag.Diagnostics {
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		kibErr, ok := decodeKibanaError(res.StatusCode, body)
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  errMsg,
+				Detail:   fmt.Sprintf("Failed with: %s", body),
+			})
+			return diags
+		}
+
+		var path cty.Path
+		for _, resolve := range resolvers {
+			if path = resolve(kibErr); path != nil {
+				break
+			}
+		}
 		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  errMsg,
-			Detail:   fmt.Sprintf("Failed with: %s", body),
+			Severity:      diag.Error,
+			Summary:       errMsg,
+			Detail:        kibErr.Error(),
+			AttributePath: path,
 		})
 		return diags
 	}
@@ -148,6 +187,22 @@ func AddConnectionSchema(providedSchema map[string]*schema.Schema) {
 	providedSchema[connectionKeyName] = providerSchema.GetEsConnectionSchema(connectionKeyName, false)
 }
 
+const kibanaConnectionKeyName = "kibana_connection"
+
+// Adds the Kibana connection schema to the Kibana resources,
+// which allows them to target a different Kibana instance than the provider
+func AddKibanaConnectionSchema(providedSchema map[string]*schema.Schema) {
+	providedSchema[kibanaConnectionKeyName] = providerSchema.GetKibanaConnectionSchema(kibanaConnectionKeyName)
+}
+
+const fleetConnectionKeyName = "fleet_connection"
+
+// Adds the Fleet connection schema to the Fleet resources,
+// which allows them to target a different Fleet server than the provider
+func AddFleetConnectionSchema(providedSchema map[string]*schema.Schema) {
+	providedSchema[fleetConnectionKeyName] = providerSchema.GetFleetConnectionSchema(fleetConnectionKeyName)
+}
+
 func StringToHash(s string) (*string, error) {
 	h := sha1.New()
 	_, err := h.Write([]byte(s))
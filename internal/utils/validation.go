@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"time"
 )
 
@@ -20,6 +21,18 @@ func StringIsDuration(i interface{}, k string) (warnings []string, errors []erro
 	return nil, nil
 }
 
+var elasticDurationRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)(d|h|m|s|ms|micros|nanos)$`)
+
+var elasticDurationUnits = map[string]time.Duration{
+	"d":      24 * time.Hour,
+	"h":      time.Hour,
+	"m":      time.Minute,
+	"s":      time.Second,
+	"ms":     time.Millisecond,
+	"micros": time.Microsecond,
+	"nanos":  time.Nanosecond,
+}
+
 // StringIsElasticDuration is a SchemaValidateFunc which tests to make sure the supplied string is valid duration using Elastic time units:
 // d, h, m, s, ms, micros, nanos. (see https://www.elastic.co/guide/en/elasticsearch/reference/current/api-conventions.html#time-units)
 func StringIsElasticDuration(i interface{}, k string) (warnings []string, errors []error) {
@@ -32,11 +45,22 @@ func StringIsElasticDuration(i interface{}, k string) (warnings []string, errors
 		return nil, []error{fmt.Errorf("%q contains an invalid duration: [empty]", k)}
 	}
 
-	r := regexp.MustCompile(`^[0-9]+(?:\.[0-9]+)?(?:d|h|m|s|ms|micros|nanos)$`)
-
-	if !r.MatchString(v) {
+	if !elasticDurationRegexp.MatchString(v) {
 		return nil, []error{fmt.Errorf("%q contains an invalid duration: not conforming to Elastic time-units format", k)}
 	}
 
 	return nil, nil
 }
+
+// ParseElasticDuration parses a duration using Elastic time units, e.g. `30d`, as validated by StringIsElasticDuration.
+func ParseElasticDuration(s string) (time.Duration, error) {
+	match := elasticDurationRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration %q: not conforming to Elastic time-units format", s)
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	return time.Duration(value * float64(elasticDurationUnits[match[2]])), nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestStringIsDuration(t *testing.T) {
@@ -93,3 +94,32 @@ func TestStringIsElasticDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestParseElasticDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "days", s: "30d", want: 30 * 24 * time.Hour},
+		{name: "fractional hours", s: "1.5h", want: 90 * time.Minute},
+		{name: "milliseconds", s: "250ms", want: 250 * time.Millisecond},
+		{name: "nanoseconds", s: "10nanos", want: 10 * time.Nanosecond},
+		{name: "invalid unit", s: "2w", wantErr: true},
+		{name: "empty", s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseElasticDuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseElasticDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseElasticDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package versionutils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/acctest/fake"
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForceNewBefore(t *testing.T) {
+	tests := []struct {
+		name            string
+		serverVersion   string
+		noClient        bool
+		wantRequiresNew bool
+	}{
+		{name: "updated in place from the version", serverVersion: "8.4.0", wantRequiresNew: false},
+		{name: "replaced on older servers", serverVersion: "8.3.3", wantRequiresNew: true},
+		{name: "replaced when the server is unknown", noClient: true, wantRequiresNew: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var meta interface{}
+			if !tt.noClient {
+				server := fake.NewServer(t)
+				server.Version = tt.serverVersion
+				server.Setenv(t)
+				client, err := clients.NewAcceptanceTestingClient()
+				require.NoError(t, err)
+				meta = client
+			}
+
+			r := &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metadata": {Type: schema.TypeString, Optional: true},
+				},
+				CustomizeDiff: versionutils.ForceNewBefore(version.Must(version.NewVersion("8.4.0")), "metadata"),
+			}
+			utils.AddConnectionSchema(r.Schema)
+
+			state := &terraform.InstanceState{ID: "id", Attributes: map[string]string{"id": "id", "metadata": `{"a":1}`}}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{"metadata": `{"a":2}`})
+			diff, err := r.SimpleDiff(context.Background(), state, config, meta)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRequiresNew, diff.Attributes["metadata"].RequiresNew)
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package versionutils
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Requirement is the range of server versions supporting an attribute.
+type Requirement struct {
+	// MinVersion is the first version supporting the attribute.
+	MinVersion *version.Version
+	// MaxVersion is the first version which no longer supports the attribute.
+	MaxVersion *version.Version
+	// Severity of the diagnostic reported when the attribute is configured for an unsupported version.
+	// Attributes reported as warnings are left out of the requests, and only reported when applying the resource as
+	// SDKv2 plans can't return warnings.
+	Severity diag.Severity
+	// NotOnServerless is set for attributes which Elasticsearch Serverless projects reject.
+	NotOnServerless bool
+	// Default is the schema default of the attribute. Like the zero value, it is accepted on all the versions when
+	// configured explicitly, as it's what the server uses when the attribute isn't sent.
+	Default interface{}
+}
+
+// Min returns the requirement for an attribute supported from the given version.
+func Min(v string) Requirement {
+	return Requirement{MinVersion: version.Must(version.NewVersion(v))}
+}
+
+// Before returns the requirement for an attribute no longer supported from the given version.
+func Before(v string) Requirement {
+	return Requirement{MaxVersion: version.Must(version.NewVersion(v))}
+}
+
+// NotOnServerless returns the requirement for an attribute unavailable on Elasticsearch Serverless.
+func NotOnServerless() Requirement {
+	return Requirement{NotOnServerless: true}
+}
+
+// WithWarning returns the requirement, reporting unsupported versions as warnings when applying the resource.
+func (r Requirement) WithWarning() Requirement {
+	r.Severity = diag.Warning
+	return r
+}
+
+// WithDefault returns the requirement of an attribute whose schema default is the given value.
+func (r Requirement) WithDefault(v interface{}) Requirement {
+	r.Default = v
+	return r
+}
+
+// Supports returns whether the given server version supports the attribute.
+func (r Requirement) Supports(serverVersion *version.Version) bool {
+	if r.MinVersion != nil && serverVersion.LessThan(r.MinVersion) {
+		return false
+	}
+	if r.MaxVersion != nil && serverVersion.GreaterThanOrEqual(r.MaxVersion) {
+		return false
+	}
+	return true
+}
+
+func (r Requirement) String() string {
+	if r.NotOnServerless {
+		return "Not available on Elasticsearch Serverless"
+	}
+	switch {
+	case r.MinVersion != nil && r.MaxVersion != nil:
+		return fmt.Sprintf("Supported from Elasticsearch version **%s** and before version **%s**", r.MinVersion, r.MaxVersion)
+	case r.MinVersion != nil:
+		return fmt.Sprintf("Supported from Elasticsearch version **%s**", r.MinVersion)
+	case r.MaxVersion != nil:
+		return fmt.Sprintf("Not supported from Elasticsearch version **%s**", r.MaxVersion)
+	}
+	return ""
+}
+
+// Requirements declares the versions supporting the attributes of a resource. The keys are the attribute
+// keys as used with schema.ResourceData, e.g. `destination.0.pipeline`.
+type Requirements map[string]Requirement
+
+// Supports returns whether the given server version supports the attribute.
+func (r Requirements) Supports(key string, serverVersion *version.Version) bool {
+	requirement, ok := r[key]
+	return !ok || requirement.Supports(serverVersion)
+}
+
+type rawConfigGetter interface {
+	GetRawConfig() cty.Value
+}
+
+// Check returns a diagnostic for each configured attribute the given server version and flavor don't support.
+func (r Requirements) Check(d rawConfigGetter, serverVersion *version.Version, serverFlavor string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := d.GetRawConfig()
+	for _, key := range r.keys() {
+		requirement := r[key]
+		serverless := requirement.NotOnServerless && serverFlavor == clients.ServerlessFlavor
+		if !serverless && requirement.Supports(serverVersion) {
+			continue
+		}
+
+		// Like with GetOk, zero values are considered unset as they aren't sent to the server. So are the defaults.
+		value := configValue(config, key)
+		if value.IsNull() || !value.IsKnown() || isZero(value) || isDefault(value, requirement.Default) {
+			continue
+		}
+
+		if serverless {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Attribute not available on Elasticsearch Serverless",
+				Detail:        fmt.Sprintf("[%s] is not available on Elasticsearch Serverless. Remove it from the configuration.", key),
+				AttributePath: attributePath(key),
+			})
+			continue
+		}
+
+		detail := fmt.Sprintf("[%s] is not supported by the target Elasticsearch server version %s. %s.", key, serverVersion, requirement)
+		if requirement.Severity == diag.Warning {
+			detail += " The attribute is ignored."
+		} else {
+			detail += " Remove it from the configuration."
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      requirement.Severity,
+			Summary:       "Attribute not supported by the Elasticsearch version",
+			Detail:        detail,
+			AttributePath: attributePath(key),
+		})
+	}
+	return diags
+}
+
+// Document appends the supported versions to the description of each attribute of the schema.
+func (r Requirements) Document(s map[string]*schema.Schema) {
+	for _, key := range r.keys() {
+		attr := schemaAt(s, key)
+		if attr == nil {
+			panic(fmt.Sprintf("version requirement declared for unknown attribute [%s]", key))
+		}
+
+		if attr.Default != nil && !reflect.ValueOf(attr.Default).IsZero() && attr.Default != r[key].Default {
+			panic(fmt.Sprintf("version requirement for attribute [%s] must declare its default %v", key, attr.Default))
+		}
+
+		note := r[key].String()
+		if strings.Contains(attr.Description, note) {
+			// Attributes shared between blocks are only documented once.
+			continue
+		}
+		attr.Description = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(attr.Description), ".") + ". " + note + ".")
+	}
+}
+
+// Apply documents the requirements in the resource schema, and checks the requirements reported as errors when
+// planning the resource.
+func (r Requirements) Apply(resource *schema.Resource) *schema.Resource {
+	r.Document(resource.Schema)
+	if resource.CustomizeDiff == nil {
+		resource.CustomizeDiff = r.customizeDiff
+	} else {
+		resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff, r.customizeDiff)
+	}
+	return resource
+}
+
+// customizeDiff fails the plan when the target server doesn't support a configured attribute. The check is skipped
+// when the server can't be determined yet, e.g. when the connection depends on other resources, and is run again on
+// apply. Warnings are left to the apply, as CustomizeDiff can only return errors.
+func (r Requirements) customizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	serverVersion, serverFlavor, ok := plannedServer(ctx, d, meta)
+	if !ok {
+		return nil
+	}
+
+	var errs []string
+	for _, d := range r.Check(d, serverVersion, serverFlavor) {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Detail)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// ResourceNotOnServerless returns the resource, failing its plan when the target server is Elasticsearch Serverless.
+func ResourceNotOnServerless(resource *schema.Resource) *schema.Resource {
+	resource.Description = strings.TrimSuffix(resource.Description, ".") + ". Not available on Elasticsearch Serverless."
+	notOnServerless := func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if _, serverFlavor, ok := plannedServer(ctx, d, meta); ok && serverFlavor == clients.ServerlessFlavor {
+			return fmt.Errorf("this resource is not available on Elasticsearch Serverless")
+		}
+		return nil
+	}
+	if resource.CustomizeDiff == nil {
+		resource.CustomizeDiff = notOnServerless
+	} else {
+		resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff, notOnServerless)
+	}
+	return resource
+}
+
+// ForceNewBefore returns a CustomizeDiff function replacing the resource when one of the attributes changes and the
+// target server is older than the given version, for attributes which can only be updated in place from that
+// version. The resource is also replaced when the server can't be determined yet.
+func ForceNewBefore(minVersion *version.Version, keys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		if serverVersion, _, ok := plannedServer(ctx, d, meta); ok && serverVersion.GreaterThanOrEqual(minVersion) {
+			return nil
+		}
+		for _, key := range keys {
+			if !d.HasChange(key) {
+				continue
+			}
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// plannedServer returns the version and flavor of the server targeted by the planned resource, and false when it
+// can't be determined yet.
+func plannedServer(ctx context.Context, d *schema.ResourceDiff, meta interface{}) (*version.Version, string, bool) {
+	if _, ok := meta.(*clients.ApiClient); !ok || !d.NewValueKnown("elasticsearch_connection") {
+		return nil, "", false
+	}
+
+	client, diags := clients.NewApiClientFromDiff(d, meta)
+	if diags.HasError() {
+		return nil, "", false
+	}
+	serverVersion, diags := client.ServerVersion(ctx)
+	if diags.HasError() {
+		tflog.Debug(ctx, "Skipping the server requirements check, the server version is unavailable")
+		return nil, "", false
+	}
+	serverFlavor, diags := client.ServerFlavor(ctx)
+	if diags.HasError() {
+		return nil, "", false
+	}
+	return serverVersion, serverFlavor, true
+}
+
+func (r Requirements) keys() []string {
+	keys := make([]string, 0, len(r))
+	for key := range r {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// configValue returns the configured value of the attribute, or null when it isn't configured.
+func configValue(config cty.Value, key string) cty.Value {
+	value := config
+	for _, step := range strings.Split(key, ".") {
+		if value.IsNull() || !value.IsKnown() {
+			return value
+		}
+
+		ty := value.Type()
+		if i, err := strconv.Atoi(step); err == nil {
+			if !(ty.IsListType() || ty.IsTupleType()) || value.LengthInt() <= i {
+				return cty.NullVal(cty.DynamicPseudoType)
+			}
+			value = value.Index(cty.NumberIntVal(int64(i)))
+			continue
+		}
+
+		if !ty.IsObjectType() || !ty.HasAttribute(step) {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		value = value.GetAttr(step)
+	}
+	return value
+}
+
+func isZero(value cty.Value) bool {
+	ty := value.Type()
+	switch {
+	case ty == cty.Bool:
+		return value.False()
+	case ty == cty.Number:
+		return value.Equals(cty.Zero).True()
+	case ty == cty.String:
+		return value.AsString() == ""
+	case ty.IsListType() || ty.IsSetType() || ty.IsMapType() || ty.IsTupleType():
+		return value.LengthInt() == 0
+	}
+	return false
+}
+
+func isDefault(value cty.Value, def interface{}) bool {
+	switch def := def.(type) {
+	case int:
+		return value.Type() == cty.Number && value.Equals(cty.NumberIntVal(int64(def))).True()
+	case float64:
+		return value.Type() == cty.Number && value.Equals(cty.NumberFloatVal(def)).True()
+	case string:
+		return value.Type() == cty.String && value.AsString() == def
+	case bool:
+		return value.Type() == cty.Bool && value.Equals(cty.BoolVal(def)).True()
+	}
+	return false
+}
+
+func attributePath(key string) cty.Path {
+	var path cty.Path
+	for _, step := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+	return path
+}
+
+func schemaAt(s map[string]*schema.Schema, key string) *schema.Schema {
+	var attr *schema.Schema
+	for _, step := range strings.Split(key, ".") {
+		if _, err := strconv.Atoi(step); err == nil {
+			continue
+		}
+		if attr != nil {
+			resource, ok := attr.Elem.(*schema.Resource)
+			if !ok {
+				return nil
+			}
+			s = resource.Schema
+		}
+		if attr = s[step]; attr == nil {
+			return nil
+		}
+	}
+	return attr
+}
@@ -0,0 +1,206 @@
+package versionutils_test
+
+import (
+	"testing"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type rawConfig cty.Value
+
+func (c rawConfig) GetRawConfig() cty.Value {
+	return cty.Value(c)
+}
+
+var requirements = versionutils.Requirements{
+	"frequency":              versionutils.Min("7.3.0").WithWarning(),
+	"include_type_name":      versionutils.Before("8.0.0"),
+	"destination.0.pipeline": versionutils.Min("7.3.0"),
+	"number_of_replicas":     versionutils.NotOnServerless(),
+	"total_shards_per_node":  versionutils.Min("7.16.0").WithDefault(-1),
+}
+
+func config(attrs map[string]cty.Value) rawConfig {
+	values := map[string]cty.Value{
+		"frequency":             cty.NullVal(cty.String),
+		"include_type_name":     cty.NullVal(cty.Bool),
+		"number_of_replicas":    cty.NullVal(cty.Number),
+		"total_shards_per_node": cty.NullVal(cty.Number),
+		"destination": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"index":    cty.StringVal("dest"),
+			"pipeline": cty.NullVal(cty.String),
+		})}),
+	}
+	for k, v := range attrs {
+		values[k] = v
+	}
+	return rawConfig(cty.ObjectVal(values))
+}
+
+func TestRequirements_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		config        rawConfig
+		serverVersion string
+		serverFlavor  string
+		expected      diag.Diagnostics
+	}{
+		{
+			name:          "unset attributes aren't reported",
+			config:        config(nil),
+			serverVersion: "7.2.0",
+		},
+		{
+			name:          "supported attributes aren't reported",
+			config:        config(map[string]cty.Value{"frequency": cty.StringVal("5m")}),
+			serverVersion: "7.3.0",
+		},
+		{
+			name:          "zero values aren't reported",
+			config:        config(map[string]cty.Value{"include_type_name": cty.False}),
+			serverVersion: "8.0.0",
+		},
+		{
+			name:          "default values aren't reported",
+			config:        config(map[string]cty.Value{"total_shards_per_node": cty.NumberIntVal(-1)}),
+			serverVersion: "7.15.0",
+		},
+		{
+			name:          "values other than the default are reported",
+			config:        config(map[string]cty.Value{"total_shards_per_node": cty.NumberIntVal(2)}),
+			serverVersion: "7.15.0",
+			expected: diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Attribute not supported by the Elasticsearch version",
+				Detail:        "[total_shards_per_node] is not supported by the target Elasticsearch server version 7.15.0. Supported from Elasticsearch version **7.16.0**. Remove it from the configuration.",
+				AttributePath: cty.GetAttrPath("total_shards_per_node"),
+			}},
+		},
+		{
+			name:          "attributes below the min version are reported",
+			config:        config(map[string]cty.Value{"frequency": cty.StringVal("5m")}),
+			serverVersion: "7.2.0",
+			expected: diag.Diagnostics{{
+				Severity:      diag.Warning,
+				Summary:       "Attribute not supported by the Elasticsearch version",
+				Detail:        "[frequency] is not supported by the target Elasticsearch server version 7.2.0. Supported from Elasticsearch version **7.3.0**. The attribute is ignored.",
+				AttributePath: cty.GetAttrPath("frequency"),
+			}},
+		},
+		{
+			name:          "attributes from the max version are reported",
+			config:        config(map[string]cty.Value{"include_type_name": cty.True}),
+			serverVersion: "8.1.0",
+			expected: diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Attribute not supported by the Elasticsearch version",
+				Detail:        "[include_type_name] is not supported by the target Elasticsearch server version 8.1.0. Not supported from Elasticsearch version **8.0.0**. Remove it from the configuration.",
+				AttributePath: cty.GetAttrPath("include_type_name"),
+			}},
+		},
+		{
+			name: "nested attributes are reported",
+			config: config(map[string]cty.Value{"destination": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"index":    cty.StringVal("dest"),
+				"pipeline": cty.StringVal("my-pipeline"),
+			})})}),
+			serverVersion: "7.2.0",
+			expected: diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Attribute not supported by the Elasticsearch version",
+				Detail:        "[destination.0.pipeline] is not supported by the target Elasticsearch server version 7.2.0. Supported from Elasticsearch version **7.3.0**. Remove it from the configuration.",
+				AttributePath: cty.GetAttrPath("destination").IndexInt(0).GetAttr("pipeline"),
+			}},
+		},
+		{
+			name:          "serverless attributes are allowed on other flavors",
+			config:        config(map[string]cty.Value{"number_of_replicas": cty.NumberIntVal(2)}),
+			serverVersion: "8.11.0",
+			serverFlavor:  "default",
+		},
+		{
+			name:          "serverless attributes are reported on serverless",
+			config:        config(map[string]cty.Value{"number_of_replicas": cty.NumberIntVal(2)}),
+			serverVersion: "8.11.0",
+			serverFlavor:  "serverless",
+			expected: diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Attribute not available on Elasticsearch Serverless",
+				Detail:        "[number_of_replicas] is not available on Elasticsearch Serverless. Remove it from the configuration.",
+				AttributePath: cty.GetAttrPath("number_of_replicas"),
+			}},
+		},
+		{
+			name:          "unknown values aren't reported",
+			config:        config(map[string]cty.Value{"destination": cty.UnknownVal(cty.List(cty.Object(map[string]cty.Type{"index": cty.String, "pipeline": cty.String})))}),
+			serverVersion: "7.2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := requirements.Check(tt.config, version.Must(version.NewVersion(tt.serverVersion)), tt.serverFlavor)
+			require.Equal(t, tt.expected, diags)
+		})
+	}
+}
+
+func TestRequirements_Supports(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, requirements.Supports("frequency", version.Must(version.NewVersion("7.3.0"))))
+	require.False(t, requirements.Supports("frequency", version.Must(version.NewVersion("7.2.1"))))
+	require.True(t, requirements.Supports("include_type_name", version.Must(version.NewVersion("7.17.0"))))
+	require.False(t, requirements.Supports("include_type_name", version.Must(version.NewVersion("8.0.0"))))
+	require.True(t, requirements.Supports("description", version.Must(version.NewVersion("7.0.0"))))
+}
+
+func TestRequirements_Document(t *testing.T) {
+	t.Parallel()
+
+	pipeline := &schema.Schema{Description: "The unique identifier for an ingest pipeline.", Type: schema.TypeString, Optional: true}
+	s := map[string]*schema.Schema{
+		"number_of_replicas":    {Description: "Number of shard replicas.", Type: schema.TypeInt, Optional: true},
+		"frequency":             {Description: "The interval between checks. Defaults to `1m`.", Type: schema.TypeString, Optional: true},
+		"include_type_name":     {Description: "If true, a mapping type is expected in the body of mappings", Type: schema.TypeBool, Optional: true},
+		"total_shards_per_node": {Description: "The maximum number of shards on a single node.", Type: schema.TypeInt, Optional: true, Default: -1},
+		"destination": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{"pipeline": pipeline},
+			},
+		},
+	}
+
+	requirements.Document(s)
+	// Documenting the requirements again, e.g. for schemas shared by several blocks, doesn't repeat them.
+	requirements.Document(s)
+
+	require.Equal(t, "The interval between checks. Defaults to `1m`. Supported from Elasticsearch version **7.3.0**.", s["frequency"].Description)
+	require.Equal(t, "If true, a mapping type is expected in the body of mappings. Not supported from Elasticsearch version **8.0.0**.", s["include_type_name"].Description)
+	require.Equal(t, "Number of shard replicas. Not available on Elasticsearch Serverless.", s["number_of_replicas"].Description)
+	require.Equal(t, "The unique identifier for an ingest pipeline. Supported from Elasticsearch version **7.3.0**.", pipeline.Description)
+}
+
+func TestRequirements_DocumentUndeclaredDefault(t *testing.T) {
+	t.Parallel()
+
+	s := map[string]*schema.Schema{
+		"total_shards_per_node": {Description: "The maximum number of shards on a single node.", Type: schema.TypeInt, Optional: true, Default: -1},
+	}
+	requirements := versionutils.Requirements{"total_shards_per_node": versionutils.Min("7.16.0")}
+	require.PanicsWithValue(t, "version requirement for attribute [total_shards_per_node] must declare its default -1", func() {
+		requirements.Document(s)
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"github.com/elastic/terraform-provider-elasticstack/internal/export"
 	"github.com/elastic/terraform-provider-elasticstack/provider"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
@@ -21,6 +23,14 @@ var (
 )
 
 func main() {
+	// `terraform-provider-elasticstack export` writes the configuration of the objects of an existing cluster.
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export.Run(context.Background(), version, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
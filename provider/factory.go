@@ -3,15 +3,19 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 )
 
 // ProtoV5ProviderServerFactory returns a muxed terraform-plugin-go protocol v5 provider factory function.
+// Resources and data sources are served either by the SDKv2 provider or by the plugin framework provider.
 func ProtoV5ProviderServerFactory(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
 	sdkv2Provider := New(version)
+	frameworkProvider := providerserver.NewProtocol5(NewFrameworkProvider(version))
 
 	servers := []func() tfprotov5.ProviderServer{
+		frameworkProvider,
 		sdkv2Provider.GRPCProvider,
 	}
 
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/elastic/terraform-provider-elasticstack/internal/clients"
+	"github.com/elastic/terraform-provider-elasticstack/internal/fleet"
+	providerSchema "github.com/elastic/terraform-provider-elasticstack/internal/schema"
+	"github.com/elastic/terraform-provider-elasticstack/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var _ fwprovider.Provider = &Provider{}
+
+// Provider is the plugin framework implementation of the provider. It's served alongside
+// the SDKv2 provider (see New), and resources are migrated to it one at a time.
+type Provider struct {
+	version string
+}
+
+// NewFrameworkProvider instantiates the plugin framework based provider.
+func NewFrameworkProvider(version string) fwprovider.Provider {
+	return &Provider{
+		version: version,
+	}
+}
+
+func (p *Provider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "elasticstack"
+	resp.Version = p.version
+}
+
+func (p *Provider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = fwschema.Schema{
+		Blocks: map[string]fwschema.Block{
+			esKeyName: providerSchema.GetEsFWConnectionBlock(),
+			"kibana":  providerSchema.GetKbFWConnectionBlock(),
+			"fleet":   providerSchema.GetFleetFWConnectionBlock(),
+			"retry":   providerSchema.GetRetryFWBlock(),
+		},
+	}
+}
+
+func (p *Provider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	client, diags := clients.NewApiClientFromFramework(ctx, req.Config, p.version)
+	resp.Diagnostics.Append(utils.FrameworkDiagsFromSDK(diags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		fleet.NewFleetServerHostResource,
+		fleet.NewOutputResource,
+		fleet.NewAgentPolicyResource,
+	}
+}
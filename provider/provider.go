@@ -28,8 +28,9 @@ func New(version string) *schema.Provider {
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			esKeyName: providerSchema.GetEsConnectionSchema(esKeyName, true),
-			"kibana":  providerSchema.GetKibanaConnectionSchema(),
-			"fleet":   providerSchema.GetFleetConnectionSchema(),
+			"kibana":  providerSchema.GetKibanaConnectionSchema("kibana"),
+			"fleet":   providerSchema.GetFleetConnectionSchema("fleet"),
+			"retry":   providerSchema.GetRetrySchema(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"elasticstack_elasticsearch_ingest_processor_append":            ingest.DataSourceProcessorAppend(),
@@ -75,38 +76,37 @@ func New(version string) *schema.Provider {
 			"elasticstack_elasticsearch_security_user":                      security.DataSourceUser(),
 			"elasticstack_elasticsearch_snapshot_repository":                cluster.DataSourceSnapshotRespository(),
 			"elasticstack_elasticsearch_enrich_policy":                      enrich.DataSourceEnrichPolicy(),
+			"elasticstack_elasticsearch_indices":                            index.DataSourceIndices(),
 
 			"elasticstack_fleet_enrollment_tokens": fleet.DataSourceEnrollmentTokens(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"elasticstack_elasticsearch_cluster_settings":      cluster.ResourceSettings(),
-			"elasticstack_elasticsearch_component_template":    index.ResourceComponentTemplate(),
-			"elasticstack_elasticsearch_data_stream":           index.ResourceDataStream(),
-			"elasticstack_elasticsearch_index":                 index.ResourceIndex(),
-			"elasticstack_elasticsearch_index_lifecycle":       index.ResourceIlm(),
-			"elasticstack_elasticsearch_index_template":        index.ResourceTemplate(),
-			"elasticstack_elasticsearch_ingest_pipeline":       ingest.ResourceIngestPipeline(),
-			"elasticstack_elasticsearch_logstash_pipeline":     logstash.ResourceLogstashPipeline(),
-			"elasticstack_elasticsearch_security_api_key":      security.ResourceApiKey(),
-			"elasticstack_elasticsearch_security_role":         security.ResourceRole(),
-			"elasticstack_elasticsearch_security_role_mapping": security.ResourceRoleMapping(),
-			"elasticstack_elasticsearch_security_user":         security.ResourceUser(),
-			"elasticstack_elasticsearch_security_system_user":  security.ResourceSystemUser(),
-			"elasticstack_elasticsearch_snapshot_lifecycle":    cluster.ResourceSlm(),
-			"elasticstack_elasticsearch_snapshot_repository":   cluster.ResourceSnapshotRepository(),
-			"elasticstack_elasticsearch_script":                cluster.ResourceScript(),
-			"elasticstack_elasticsearch_enrich_policy":         enrich.ResourceEnrichPolicy(),
-			"elasticstack_elasticsearch_transform":             transform.ResourceTransform(),
-			"elasticstack_elasticsearch_watch":                 watcher.ResourceWatch(),
+			"elasticstack_elasticsearch_cluster_settings":               cluster.ResourceSettings(),
+			"elasticstack_elasticsearch_component_template":             index.ResourceComponentTemplate(),
+			"elasticstack_elasticsearch_data_stream":                    index.ResourceDataStream(),
+			"elasticstack_elasticsearch_index":                          index.ResourceIndex(),
+			"elasticstack_elasticsearch_index_alias":                    index.ResourceIndexAlias(),
+			"elasticstack_elasticsearch_index_lifecycle":                index.ResourceIlm(),
+			"elasticstack_elasticsearch_index_template":                 index.ResourceTemplate(),
+			"elasticstack_elasticsearch_ingest_pipeline":                ingest.ResourceIngestPipeline(),
+			"elasticstack_elasticsearch_logstash_pipeline":              logstash.ResourceLogstashPipeline(),
+			"elasticstack_elasticsearch_security_api_key":               security.ResourceApiKey(),
+			"elasticstack_elasticsearch_security_cross_cluster_api_key": security.ResourceCrossClusterApiKey(),
+			"elasticstack_elasticsearch_security_role":                  security.ResourceRole(),
+			"elasticstack_elasticsearch_security_role_mapping":          security.ResourceRoleMapping(),
+			"elasticstack_elasticsearch_security_user":                  security.ResourceUser(),
+			"elasticstack_elasticsearch_security_system_user":           security.ResourceSystemUser(),
+			"elasticstack_elasticsearch_snapshot_lifecycle":             cluster.ResourceSlm(),
+			"elasticstack_elasticsearch_snapshot_repository":            cluster.ResourceSnapshotRepository(),
+			"elasticstack_elasticsearch_script":                         cluster.ResourceScript(),
+			"elasticstack_elasticsearch_enrich_policy":                  enrich.ResourceEnrichPolicy(),
+			"elasticstack_elasticsearch_transform":                      transform.ResourceTransform(),
+			"elasticstack_elasticsearch_watch":                          watcher.ResourceWatch(),
 
 			"elasticstack_kibana_alerting_rule":    kibana.ResourceAlertingRule(),
 			"elasticstack_kibana_space":            kibana.ResourceSpace(),
 			"elasticstack_kibana_action_connector": kibana.ResourceActionConnector(),
 			"elasticstack_kibana_slo":              kibana.ResourceSlo(),
-
-			"elasticstack_fleet_agent_policy": fleet.ResourceAgentPolicy(),
-			"elasticstack_fleet_output":       fleet.ResourceOutput(),
-			"elasticstack_fleet_server_host":  fleet.ResourceFleetServerHost(),
 		},
 	}
 
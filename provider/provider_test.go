@@ -1,6 +1,7 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"github.com/elastic/terraform-provider-elasticstack/internal/elasticsearch/security"
 	"github.com/elastic/terraform-provider-elasticstack/internal/versionutils"
 	"github.com/elastic/terraform-provider-elasticstack/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -19,6 +22,59 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestMuxServer(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := provider.ProtoV5ProviderServerFactory(ctx, "dev")
+	if err != nil {
+		t.Fatalf("Failed to create the mux server: %s", err)
+	}
+
+	server := serverFactory()
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("Failed to get the provider schema: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("Unexpected provider schema error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	// Both servers must agree on the prepared provider configuration. Terraform sends unset blocks as empty lists.
+	configType := resp.Provider.ValueType().(tftypes.Object)
+	kibanaType := configType.AttributeTypes["kibana"].(tftypes.List).ElementType.(tftypes.Object)
+	kibana := nullAttributes(kibanaType)
+	kibana["username"] = tftypes.NewValue(tftypes.String, "elastic")
+	kibana["password"] = tftypes.NewValue(tftypes.String, "changeme")
+	config := map[string]tftypes.Value{}
+	for name, blockType := range configType.AttributeTypes {
+		config[name] = tftypes.NewValue(blockType, []tftypes.Value{})
+	}
+	config["kibana"] = tftypes.NewValue(configType.AttributeTypes["kibana"], []tftypes.Value{tftypes.NewValue(kibanaType, kibana)})
+	dynamicConfig, err := tfprotov5.NewDynamicValue(configType, tftypes.NewValue(configType, config))
+	if err != nil {
+		t.Fatalf("Failed to build the provider configuration: %s", err)
+	}
+
+	prepareResp, err := server.PrepareProviderConfig(ctx, &tfprotov5.PrepareProviderConfigRequest{Config: &dynamicConfig})
+	if err != nil {
+		t.Fatalf("Failed to prepare the provider configuration: %s", err)
+	}
+	for _, d := range prepareResp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("Unexpected provider configuration error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func nullAttributes(objectType tftypes.Object) map[string]tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	return values
+}
+
 func TestElasticsearchAPIKeyConnection(t *testing.T) {
 	apiKeyName := sdkacctest.RandStringFromCharSet(10, sdkacctest.CharSetAlphaNum)
 	resource.Test(t, resource.TestCase{